@@ -0,0 +1,488 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	composeNetworkLabel     = "com.docker.compose.network"
+	composeVolumeLabel      = "com.docker.compose.volume"
+	composeNumberLabel      = "com.docker.compose.container-number"
+	composeOneoffLabel      = "com.docker.compose.oneoff"
+	composeConfigFilesLabel = "com.docker.compose.project.config_files"
+	composeWorkingDirLabel  = "com.docker.compose.project.working_dir"
+)
+
+type DockerComposeService struct {
+	DockerBaseService
+}
+
+type ComposeProjectInfo struct {
+	Name       string               `json:"name"`
+	File       string               `json:"file"`
+	WorkingDir string               `json:"workingDir"`
+	Services   []ComposeServiceInfo `json:"services"`
+	Networks   []string             `json:"networks"`
+	Volumes    []string             `json:"volumes"`
+}
+
+type ComposeServiceInfo struct {
+	Name          string   `json:"name"`
+	Image         string   `json:"image"`
+	ContainerName string   `json:"containerName"`
+	DependsOn     []string `json:"dependsOn"`
+}
+
+func NewDockerComposeService() *DockerComposeService {
+	return &DockerComposeService{}
+}
+
+func StartupDockerComposeService(s *DockerComposeService, ctx context.Context, cli *client.Client) {
+	s.ctx = ctx
+	s.cli = cli
+}
+
+// Discover returns the compose files found in dir and its direct
+// subdirectories.
+func (s *DockerComposeService) Discover(dir string) ([]string, error) {
+	files := []string{}
+	if path, err := findComposeFile(dir); err == nil {
+		files = append(files, path)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if path, err := findComposeFile(filepath.Join(dir, entry.Name())); err == nil {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+func (s *DockerComposeService) OpenFile() (string, error) {
+	if s.ctx == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+
+	path, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+		Title:   "Open Compose File",
+		Filters: []runtime.FileFilter{{DisplayName: "Compose Files", Pattern: "*.yaml;*.yml"}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("dialog error: %w", err)
+	}
+	if path == "" {
+		return "", fmt.Errorf("no file selected")
+	}
+	return path, nil
+}
+
+func (s *DockerComposeService) Load(path string) (ComposeProjectInfo, error) {
+	project, err := loadComposeProject(path)
+	if err != nil {
+		return ComposeProjectInfo{}, err
+	}
+
+	info := ComposeProjectInfo{
+		Name:       project.Name,
+		File:       project.File,
+		WorkingDir: project.WorkingDir,
+		Services:   []ComposeServiceInfo{},
+		Networks:   []string{},
+		Volumes:    []string{},
+	}
+
+	order, err := project.serviceOrder()
+	if err != nil {
+		return ComposeProjectInfo{}, err
+	}
+	for _, name := range order {
+		service := project.Services[name]
+		dependsOn := make([]string, 0, len(service.DependsOn))
+		for dependency := range service.DependsOn {
+			dependsOn = append(dependsOn, dependency)
+		}
+		sort.Strings(dependsOn)
+		info.Services = append(info.Services, ComposeServiceInfo{
+			Name:          name,
+			Image:         service.Image,
+			ContainerName: project.containerName(name),
+			DependsOn:     dependsOn,
+		})
+	}
+	for key := range project.Networks {
+		info.Networks = append(info.Networks, project.networkName(key))
+	}
+	sort.Strings(info.Networks)
+	for key := range project.Volumes {
+		info.Volumes = append(info.Volumes, project.volumeName(key))
+	}
+	sort.Strings(info.Volumes)
+
+	return info, nil
+}
+
+// Up creates the missing networks, volumes and containers of the compose file
+// and starts the services in dependency order.
+func (s *DockerComposeService) Up(path string) ([]ContainerActionResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	project, err := loadComposeProject(path)
+	if err != nil {
+		return nil, err
+	}
+	order, err := project.serviceOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNetworks(project); err != nil {
+		return nil, err
+	}
+	if err := s.ensureVolumes(project); err != nil {
+		return nil, err
+	}
+
+	failed := make(map[string]bool)
+	results := make([]ContainerActionResult, 0, len(order))
+	for _, name := range order {
+		result := ContainerActionResult{
+			Name:    project.containerName(name),
+			Service: name,
+		}
+
+		for dependency := range project.Services[name].DependsOn {
+			if failed[dependency] {
+				result.Error = fmt.Sprintf("skipped: dependency %q failed", dependency)
+			}
+		}
+		if result.Error == "" {
+			id, err := s.upService(project, name)
+			result.ID = id
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Success = true
+			}
+		}
+
+		failed[name] = !result.Success
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Down stops and removes the containers and networks of the project. Named
+// volumes are only removed when removeVolumes is set.
+func (s *DockerComposeService) Down(path string, removeVolumes bool) ([]ContainerActionResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	project, err := loadComposeProject(path)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.runGroupAction(project.Name, groupAction{
+		run: func(ctx context.Context, c container.Summary) error {
+			if c.State == container.StateRunning || c.State == container.StatePaused {
+				if err := s.cli.ContainerStop(ctx, c.ID, container.StopOptions{}); err != nil {
+					return err
+				}
+			}
+			return s.cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{RemoveVolumes: true})
+		},
+		reverse: true,
+	})
+	if err != nil && !errors.Is(err, errNoProjectContainers) {
+		return nil, err
+	}
+	if results == nil {
+		results = []ContainerActionResult{}
+	}
+
+	projectFilter := filters.NewArgs()
+	projectFilter.Add("label", composeProjectLabel+"="+project.Name)
+
+	networks, err := s.cli.NetworkList(s.ctx, network.ListOptions{Filters: projectFilter})
+	if err != nil {
+		return results, fmt.Errorf("failed to list networks: %v", err)
+	}
+	for _, n := range networks {
+		if err := s.cli.NetworkRemove(s.ctx, n.ID); err != nil {
+			return results, fmt.Errorf("failed to remove network %s: %v", n.Name, err)
+		}
+	}
+
+	if removeVolumes {
+		volumes, err := s.cli.VolumeList(s.ctx, volume.ListOptions{Filters: projectFilter})
+		if err != nil {
+			return results, fmt.Errorf("failed to list volumes: %v", err)
+		}
+		for _, v := range volumes.Volumes {
+			if err := s.cli.VolumeRemove(s.ctx, v.Name, false); err != nil {
+				return results, fmt.Errorf("failed to remove volume %s: %v", v.Name, err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (s *DockerComposeService) ensureNetworks(project *composeProject) error {
+	for key, definition := range project.Networks {
+		name := project.networkName(key)
+		_, err := s.cli.NetworkInspect(s.ctx, name, network.InspectOptions{})
+		if err == nil {
+			continue
+		}
+		if !cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("failed to inspect network %s: %v", name, err)
+		}
+		if definition.External {
+			return fmt.Errorf("external network %s not found", name)
+		}
+
+		labels := map[string]string{
+			composeProjectLabel: project.Name,
+			composeNetworkLabel: key,
+		}
+		for k, v := range definition.Labels {
+			labels[k] = v
+		}
+		_, err = s.cli.NetworkCreate(s.ctx, name, network.CreateOptions{
+			Driver:     definition.Driver,
+			Options:    definition.DriverOpts,
+			Internal:   definition.Internal,
+			Attachable: definition.Attachable,
+			Labels:     labels,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create network %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *DockerComposeService) ensureVolumes(project *composeProject) error {
+	for key, definition := range project.Volumes {
+		name := project.volumeName(key)
+		_, err := s.cli.VolumeInspect(s.ctx, name)
+		if err == nil {
+			continue
+		}
+		if !cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("failed to inspect volume %s: %v", name, err)
+		}
+		if definition.External {
+			return fmt.Errorf("external volume %s not found", name)
+		}
+
+		labels := map[string]string{
+			composeProjectLabel: project.Name,
+			composeVolumeLabel:  key,
+		}
+		for k, v := range definition.Labels {
+			labels[k] = v
+		}
+		_, err = s.cli.VolumeCreate(s.ctx, volume.CreateOptions{
+			Name:       name,
+			Driver:     definition.Driver,
+			DriverOpts: definition.DriverOpts,
+			Labels:     labels,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create volume %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// upService starts the existing container of a service or creates it first.
+func (s *DockerComposeService) upService(project *composeProject, name string) (string, error) {
+	containerName := project.containerName(name)
+
+	existing, err := s.cli.ContainerInspect(s.ctx, containerName)
+	if err == nil {
+		if existing.Config.Labels[composeProjectLabel] != project.Name {
+			return "", fmt.Errorf("container name %s is already in use", containerName)
+		}
+		if err := s.cli.ContainerStart(s.ctx, existing.ID, container.StartOptions{}); err != nil {
+			return existing.ID[:12], fmt.Errorf("failed to start container: %v", err)
+		}
+		return existing.ID[:12], nil
+	}
+	if !cerrdefs.IsNotFound(err) {
+		return "", fmt.Errorf("failed to inspect container: %v", err)
+	}
+
+	spec, err := project.containerSpec(name)
+	if err != nil {
+		return "", err
+	}
+	if err := spec.Validate(); err != nil {
+		return "", err
+	}
+	if err := s.ensureImage(spec.Image); err != nil {
+		return "", err
+	}
+
+	config, hostConfig, networkingConfig, err := spec.build()
+	if err != nil {
+		return "", err
+	}
+	for _, port := range project.Services[name].Expose {
+		port, protocol, _ := strings.Cut(port, "/")
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		config.ExposedPorts[nat.Port(port+"/"+protocol)] = struct{}{}
+	}
+	for key, settings := range project.Services[name].Networks {
+		endpoint := networkingConfig.EndpointsConfig[project.networkName(key)]
+		endpoint.Aliases = append([]string{name}, settings.Aliases...)
+		if settings.IPv4Address != "" {
+			endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: settings.IPv4Address}
+		}
+	}
+
+	resp, err := s.createContainer(config, hostConfig, networkingConfig, containerName)
+	if err != nil {
+		return "", err
+	}
+	if err := s.cli.ContainerStart(s.ctx, resp.ID, container.StartOptions{}); err != nil {
+		return resp.ID[:12], fmt.Errorf("failed to start container: %v", err)
+	}
+	return resp.ID[:12], nil
+}
+
+func (s *DockerBaseService) ensureImage(ref string) error {
+	_, err := s.cli.ImageInspect(s.ctx, ref)
+	if err == nil {
+		return nil
+	}
+	if !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to inspect image %s: %v", ref, err)
+	}
+
+	auth, err := encodedRegistryAuthForRef(ref)
+	if err != nil {
+		return err
+	}
+
+	reader, err := s.cli.ImagePull(s.ctx, ref, image.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %v", ref, err)
+	}
+	defer reader.Close()
+
+	// The daemon reports pull failures inside the stream, not as a status
+	if err := trackProgress(reader, &TransferProgress{}, func() {}); err != nil {
+		return fmt.Errorf("failed to pull image %s: %v", ref, err)
+	}
+	return nil
+}
+
+// containerSpec translates a compose service into a ContainerSpec carrying
+// the labels docker compose puts on its containers.
+func (p *composeProject) containerSpec(name string) (ContainerSpec, error) {
+	service := p.Services[name]
+
+	labels := map[string]string{
+		composeProjectLabel:     p.Name,
+		composeServiceLabel:     name,
+		composeNumberLabel:      "1",
+		composeOneoffLabel:      "False",
+		composeConfigFilesLabel: p.File,
+		composeWorkingDirLabel:  p.WorkingDir,
+	}
+	if len(service.DependsOn) > 0 {
+		labels[composeDependsOnLabel] = service.DependsOn.label()
+	}
+	for k, v := range service.Labels {
+		labels[k] = v
+	}
+
+	spec := ContainerSpec{
+		Name:          p.containerName(name),
+		Image:         service.Image,
+		Cmd:           service.Command,
+		Entrypoint:    service.Entrypoint,
+		Env:           service.Environment,
+		WorkingDir:    service.WorkingDir,
+		User:          service.User,
+		Tty:           service.Tty,
+		RestartPolicy: service.Restart,
+		Labels:        labels,
+	}
+
+	if policy, retries, ok := strings.Cut(service.Restart, ":"); ok {
+		spec.RestartPolicy = policy
+		if _, err := fmt.Sscanf(retries, "%d", &spec.MaxRetries); err != nil {
+			return ContainerSpec{}, fmt.Errorf("service %q: invalid restart policy %q", name, service.Restart)
+		}
+	}
+
+	for _, port := range service.Ports {
+		ports, err := port.expand()
+		if err != nil {
+			return ContainerSpec{}, fmt.Errorf("service %q: %v", name, err)
+		}
+		spec.Ports = append(spec.Ports, ports...)
+	}
+
+	for _, v := range service.Volumes {
+		m := MountSpec{
+			Type:     v.Type,
+			Source:   v.Source,
+			Target:   v.Target,
+			ReadOnly: v.ReadOnly,
+		}
+		switch v.Type {
+		case "bind":
+			m.Source = resolveHostPath(p.WorkingDir, v.Source)
+		case "volume":
+			if v.Source != "" {
+				m.Source = p.volumeName(v.Source)
+			}
+		}
+		spec.Mounts = append(spec.Mounts, m)
+	}
+
+	keys := make([]string, 0, len(service.Networks))
+	for key := range service.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		spec.Networks = append(spec.Networks, p.networkName(key))
+	}
+
+	return spec, nil
+}
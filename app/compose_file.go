@@ -0,0 +1,830 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var composeFileNames = []string{
+	"compose.yaml",
+	"compose.yml",
+	"docker-compose.yaml",
+	"docker-compose.yml",
+}
+
+var projectNameRegexp = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+type composeProject struct {
+	Name       string
+	File       string
+	WorkingDir string
+	Services   map[string]*composeService
+	Networks   map[string]*composeNetwork
+	Volumes    map[string]*composeVolume
+}
+
+type composeFile struct {
+	Name     string                     `yaml:"name"`
+	Services map[string]*composeService `yaml:"services"`
+	Networks map[string]*composeNetwork `yaml:"networks"`
+	Volumes  map[string]*composeVolume  `yaml:"volumes"`
+}
+
+type composeService struct {
+	Image         string                 `yaml:"image"`
+	ContainerName string                 `yaml:"container_name"`
+	Command       composeCommand         `yaml:"command"`
+	Entrypoint    composeCommand         `yaml:"entrypoint"`
+	Environment   composeMapping         `yaml:"environment"`
+	Labels        composeMapping         `yaml:"labels"`
+	Ports         []composePort          `yaml:"ports"`
+	Expose        []string               `yaml:"expose"`
+	Volumes       []composeServiceVolume `yaml:"volumes"`
+	Networks      composeServiceNetworks `yaml:"networks"`
+	DependsOn     composeDependsOn       `yaml:"depends_on"`
+	Restart       string                 `yaml:"restart"`
+	WorkingDir    string                 `yaml:"working_dir"`
+	User          string                 `yaml:"user"`
+	Tty           bool                   `yaml:"tty"`
+	Build         *yaml.Node             `yaml:"build"`
+}
+
+type composeNetwork struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	External   bool              `yaml:"external"`
+	Internal   bool              `yaml:"internal"`
+	Attachable bool              `yaml:"attachable"`
+	Labels     composeMapping    `yaml:"labels"`
+}
+
+type composeVolume struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	External   bool              `yaml:"external"`
+	Labels     composeMapping    `yaml:"labels"`
+}
+
+// composeCommand accepts both the string and the list form of command and
+// entrypoint.
+type composeCommand []string
+
+func (c *composeCommand) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		args, err := splitCommand(node.Value)
+		if err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*c = list
+	return nil
+}
+
+// composeMapping accepts both the "KEY=VALUE" list and the map form used by
+// environment and labels. Keys without a value are resolved from the
+// environment.
+type composeMapping map[string]string
+
+func (m *composeMapping) UnmarshalYAML(node *yaml.Node) error {
+	result := make(composeMapping)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			key, value, ok := strings.Cut(item.Value, "=")
+			if !ok {
+				value, ok = os.LookupEnv(key)
+				if !ok {
+					continue
+				}
+			}
+			result[key] = value
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if value.Tag == "!!null" {
+				env, ok := os.LookupEnv(key)
+				if !ok {
+					continue
+				}
+				result[key] = env
+				continue
+			}
+			result[key] = value.Value
+		}
+	default:
+		return fmt.Errorf("line %d: expected a list or a mapping", node.Line)
+	}
+	*m = result
+	return nil
+}
+
+type composePort struct {
+	HostIP    string
+	Published string
+	Target    string
+	Protocol  string
+}
+
+func (p *composePort) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return p.parse(node.Value)
+	}
+	var long struct {
+		Target    string `yaml:"target"`
+		Published string `yaml:"published"`
+		HostIP    string `yaml:"host_ip"`
+		Protocol  string `yaml:"protocol"`
+	}
+	if err := node.Decode(&long); err != nil {
+		return err
+	}
+	*p = composePort{
+		HostIP:    long.HostIP,
+		Published: long.Published,
+		Target:    long.Target,
+		Protocol:  long.Protocol,
+	}
+	return nil
+}
+
+// parse reads the short "[HOST_IP:][HOST_PORT:]CONTAINER_PORT[/PROTOCOL]"
+// syntax.
+func (p *composePort) parse(value string) error {
+	spec, protocol, _ := strings.Cut(value, "/")
+	p.Protocol = protocol
+
+	idx := strings.LastIndex(spec, ":")
+	if idx < 0 {
+		p.Target = spec
+		return nil
+	}
+	p.Target = spec[idx+1:]
+	spec = spec[:idx]
+
+	idx = strings.LastIndex(spec, ":")
+	if idx < 0 {
+		p.Published = spec
+		return nil
+	}
+	p.Published = spec[idx+1:]
+	p.HostIP = strings.Trim(spec[:idx], "[]")
+	return nil
+}
+
+// expand turns port ranges such as "8000-8002:8000-8002" into single ports.
+func (p composePort) expand() ([]PortSpec, error) {
+	targetStart, targetEnd, err := parsePortRange(p.Target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %v", p.Target, err)
+	}
+
+	publishedStart, publishedEnd := 0, 0
+	if p.Published != "" {
+		publishedStart, publishedEnd, err = parsePortRange(p.Published)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %v", p.Published, err)
+		}
+		if publishedEnd-publishedStart != targetEnd-targetStart {
+			return nil, fmt.Errorf("port ranges %q and %q don't match", p.Published, p.Target)
+		}
+	}
+
+	ports := make([]PortSpec, 0, targetEnd-targetStart+1)
+	for i := 0; i <= targetEnd-targetStart; i++ {
+		port := PortSpec{
+			HostIP:        p.HostIP,
+			ContainerPort: strconv.Itoa(targetStart + i),
+			Protocol:      p.Protocol,
+		}
+		if publishedStart > 0 {
+			port.HostPort = strconv.Itoa(publishedStart + i)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+type composeServiceVolume struct {
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+func (v *composeServiceVolume) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return v.parse(node.Value)
+	}
+	var long struct {
+		Type     string `yaml:"type"`
+		Source   string `yaml:"source"`
+		Target   string `yaml:"target"`
+		ReadOnly bool   `yaml:"read_only"`
+	}
+	if err := node.Decode(&long); err != nil {
+		return err
+	}
+	*v = composeServiceVolume{
+		Type:     long.Type,
+		Source:   long.Source,
+		Target:   long.Target,
+		ReadOnly: long.ReadOnly,
+	}
+	if v.Type == "" {
+		v.Type = "volume"
+	}
+	return nil
+}
+
+// parse reads the short "[SOURCE:]TARGET[:MODE]" syntax.
+func (v *composeServiceVolume) parse(value string) error {
+	parts := strings.Split(value, ":")
+	switch len(parts) {
+	case 1:
+		v.Target = parts[0]
+	case 2:
+		v.Source, v.Target = parts[0], parts[1]
+	case 3:
+		v.Source, v.Target = parts[0], parts[1]
+		for _, mode := range strings.Split(parts[2], ",") {
+			if mode == "ro" {
+				v.ReadOnly = true
+			}
+		}
+	default:
+		return fmt.Errorf("invalid volume %q", value)
+	}
+
+	v.Type = "volume"
+	if isHostPath(v.Source) {
+		v.Type = "bind"
+	}
+	return nil
+}
+
+type composeServiceNetwork struct {
+	Aliases     []string `yaml:"aliases"`
+	IPv4Address string   `yaml:"ipv4_address"`
+}
+
+type composeServiceNetworks map[string]*composeServiceNetwork
+
+func (n *composeServiceNetworks) UnmarshalYAML(node *yaml.Node) error {
+	result := make(composeServiceNetworks)
+	if node.Kind == yaml.SequenceNode {
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		for _, name := range names {
+			result[name] = &composeServiceNetwork{}
+		}
+		*n = result
+		return nil
+	}
+	var networks map[string]*composeServiceNetwork
+	if err := node.Decode(&networks); err != nil {
+		return err
+	}
+	for name, network := range networks {
+		if network == nil {
+			network = &composeServiceNetwork{}
+		}
+		result[name] = network
+	}
+	*n = result
+	return nil
+}
+
+type composeDependency struct {
+	Condition string `yaml:"condition"`
+	Restart   bool   `yaml:"restart"`
+}
+
+type composeDependsOn map[string]composeDependency
+
+func (d *composeDependsOn) UnmarshalYAML(node *yaml.Node) error {
+	result := make(composeDependsOn)
+	if node.Kind == yaml.SequenceNode {
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		for _, name := range names {
+			result[name] = composeDependency{Condition: "service_started"}
+		}
+		*d = result
+		return nil
+	}
+	var dependencies map[string]composeDependency
+	if err := node.Decode(&dependencies); err != nil {
+		return err
+	}
+	for name, dependency := range dependencies {
+		if dependency.Condition == "" {
+			dependency.Condition = "service_started"
+		}
+		result[name] = dependency
+	}
+	*d = result
+	return nil
+}
+
+// label formats the dependencies the way docker compose stores them in the
+// com.docker.compose.depends_on label.
+func (d composeDependsOn) label() string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]string, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, fmt.Sprintf("%s:%s:%t", name, d[name].Condition, d[name].Restart))
+	}
+	return strings.Join(entries, ",")
+}
+
+// findComposeFile returns the compose file in dir using the same lookup order
+// as docker compose.
+func findComposeFile(dir string) (string, error) {
+	for _, name := range composeFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no compose file found in %s", dir)
+}
+
+func loadComposeProject(path string) (*composeProject, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if path, err = findComposeFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %v", err)
+	}
+
+	workingDir := filepath.Dir(path)
+	env, err := loadDotEnv(filepath.Join(workingDir, ".env"))
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file: %v", err)
+	}
+	if err := interpolateNode(&root, env); err != nil {
+		return nil, err
+	}
+	var file composeFile
+	if err := root.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file: %v", err)
+	}
+	if len(file.Services) == 0 {
+		return nil, fmt.Errorf("compose file %s defines no services", path)
+	}
+
+	project := &composeProject{
+		Name:       normalizeProjectName(file.Name),
+		File:       path,
+		WorkingDir: workingDir,
+		Services:   file.Services,
+		Networks:   file.Networks,
+		Volumes:    file.Volumes,
+	}
+	if project.Name == "" {
+		project.Name = normalizeProjectName(filepath.Base(workingDir))
+	}
+	if project.Networks == nil {
+		project.Networks = make(map[string]*composeNetwork)
+	}
+	if project.Volumes == nil {
+		project.Volumes = make(map[string]*composeVolume)
+	}
+
+	for name, service := range project.Services {
+		if service == nil {
+			return nil, fmt.Errorf("service %q is empty", name)
+		}
+		if service.Image == "" {
+			if service.Build != nil {
+				return nil, fmt.Errorf("service %q: build sections are not supported, set an image", name)
+			}
+			return nil, fmt.Errorf("service %q has no image", name)
+		}
+		if len(service.Networks) == 0 {
+			service.Networks = composeServiceNetworks{"default": {}}
+		}
+		if _, ok := service.Networks["default"]; ok && project.Networks["default"] == nil {
+			project.Networks["default"] = &composeNetwork{}
+		}
+		for network := range service.Networks {
+			if _, ok := project.Networks[network]; !ok && network != "default" {
+				return nil, fmt.Errorf("service %q refers to undefined network %q", name, network)
+			}
+		}
+		for _, v := range service.Volumes {
+			switch v.Type {
+			case "bind", "volume", "tmpfs":
+			default:
+				return nil, fmt.Errorf("service %q: unsupported volume type %q", name, v.Type)
+			}
+			if v.Type != "volume" || v.Source == "" {
+				continue
+			}
+			if _, ok := project.Volumes[v.Source]; !ok {
+				return nil, fmt.Errorf("service %q refers to undefined volume %q", name, v.Source)
+			}
+		}
+		for dependency := range service.DependsOn {
+			if _, ok := project.Services[dependency]; !ok {
+				return nil, fmt.Errorf("service %q depends on undefined service %q", name, dependency)
+			}
+		}
+	}
+	for key, network := range project.Networks {
+		if network == nil {
+			project.Networks[key] = &composeNetwork{}
+		}
+	}
+	for key, volume := range project.Volumes {
+		if volume == nil {
+			project.Volumes[key] = &composeVolume{}
+		}
+	}
+
+	return project, nil
+}
+
+// networkName returns the engine name of a network declared in the file.
+func (p *composeProject) networkName(key string) string {
+	network := p.Networks[key]
+	if network != nil && network.Name != "" {
+		return network.Name
+	}
+	if network != nil && network.External {
+		return key
+	}
+	return p.Name + "_" + key
+}
+
+// volumeName returns the engine name of a volume declared in the file.
+func (p *composeProject) volumeName(key string) string {
+	volume := p.Volumes[key]
+	if volume != nil && volume.Name != "" {
+		return volume.Name
+	}
+	if volume != nil && volume.External {
+		return key
+	}
+	return p.Name + "_" + key
+}
+
+func (p *composeProject) containerName(service string) string {
+	if name := p.Services[service].ContainerName; name != "" {
+		return name
+	}
+	return p.Name + "-" + service + "-1"
+}
+
+// serviceOrder returns the services sorted so that every service comes after
+// its dependencies.
+func (p *composeProject) serviceOrder() ([]string, error) {
+	names := make([]string, 0, len(p.Services))
+	for name := range p.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+	order := make([]string, 0, len(names))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("dependency cycle detected at service %q", name)
+		}
+		visiting[name] = true
+		dependencies := make([]string, 0, len(p.Services[name].DependsOn))
+		for dependency := range p.Services[name].DependsOn {
+			dependencies = append(dependencies, dependency)
+		}
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func normalizeProjectName(name string) string {
+	return projectNameRegexp.ReplaceAllString(strings.ToLower(name), "")
+}
+
+func isHostPath(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~") || filepath.IsAbs(source)
+}
+
+// resolveHostPath expands bind mount sources relative to the project directory.
+func resolveHostPath(workingDir string, source string) string {
+	if strings.HasPrefix(source, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			source = filepath.Join(home, source[1:])
+		}
+	}
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(workingDir, source)
+	}
+	return filepath.Clean(source)
+}
+
+func parsePortRange(value string) (int, int, error) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+	start, err := parsePortNumber(startValue)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	end, err := parsePortNumber(endValue)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("invalid range")
+	}
+	return start, end, nil
+}
+
+// loadDotEnv reads KEY=VALUE pairs from an optional .env file.
+func loadDotEnv(path string) (map[string]string, error) {
+	env := make(map[string]string)
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return env, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			continue
+		}
+		env[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return env, scanner.Err()
+}
+
+// interpolateNode interpolates the scalar values of a parsed compose file.
+// Like docker compose it leaves keys and comments alone, and as it runs after
+// parsing the substituted values cannot change the structure of the file.
+func interpolateNode(node *yaml.Node, env map[string]string) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		value, err := interpolate(node.Value, env)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", node.Line, err)
+		}
+		if value != node.Value {
+			node.Value = value
+			// Let unquoted values resolve again, e.g. "${TTY}" to a bool
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				node.Tag = ""
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := interpolateNode(node.Content[i], env); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, child := range node.Content {
+			if err := interpolateNode(child, env); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// interpolate substitutes $VAR, ${VAR}, ${VAR:-default}, ${VAR-default},
+// ${VAR:?message} and ${VAR?message} using the process environment first and
+// the .env file second. Defaults may contain variables themselves. "$$" is an
+// escaped dollar sign.
+func interpolate(text string, env map[string]string) (string, error) {
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := env[name]
+		return value, ok
+	}
+	return substituteVariables(text, lookup)
+}
+
+func substituteVariables(text string, lookup func(string) (string, bool)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' || i+1 == len(text) {
+			b.WriteByte(text[i])
+			continue
+		}
+
+		next := text[i+1]
+		switch {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := closingBrace(text, i+1)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable %q", text[i:])
+			}
+			value, err := expandVariable(text[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i = end
+		case next == '_' || isAlpha(next):
+			j := i + 1
+			for j < len(text) && isNameChar(text[j]) {
+				j++
+			}
+			value, _ := lookup(text[i+1 : j])
+			b.WriteString(value)
+			i = j - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// closingBrace returns the index of the brace closing the one at open,
+// skipping over nested ${...} expressions.
+func closingBrace(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func expandVariable(expr string, lookup func(string) (string, bool)) (string, error) {
+	n := 0
+	for n < len(expr) && isNameChar(expr[n]) {
+		n++
+	}
+	name, modifier := expr[:n], expr[n:]
+	if name == "" {
+		return "", fmt.Errorf("invalid variable ${%s}", expr)
+	}
+
+	value, found := lookup(name)
+	switch {
+	case modifier == "":
+		return value, nil
+	case strings.HasPrefix(modifier, ":-"):
+		if found && value != "" {
+			return value, nil
+		}
+		return substituteVariables(modifier[2:], lookup)
+	case strings.HasPrefix(modifier, "-"):
+		if found {
+			return value, nil
+		}
+		return substituteVariables(modifier[1:], lookup)
+	case strings.HasPrefix(modifier, ":?"):
+		if found && value != "" {
+			return value, nil
+		}
+		return "", requiredVariableError(name, modifier[2:], lookup)
+	case strings.HasPrefix(modifier, "?"):
+		if found {
+			return value, nil
+		}
+		return "", requiredVariableError(name, modifier[1:], lookup)
+	}
+	return "", fmt.Errorf("invalid variable ${%s}", expr)
+}
+
+func requiredVariableError(name string, message string, lookup func(string) (string, bool)) error {
+	message, err := substituteVariables(message, lookup)
+	if err != nil {
+		return err
+	}
+	if message == "" {
+		return fmt.Errorf("required variable %s is missing a value", name)
+	}
+	return fmt.Errorf("required variable %s is missing a value: %s", name, message)
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || isAlpha(c) || (c >= '0' && c <= '9')
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// splitCommand splits a command string into arguments honouring single and
+// double quotes.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command %q", command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
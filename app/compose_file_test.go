@@ -0,0 +1,409 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadComposeProjectRepoFile(t *testing.T) {
+	project, err := loadComposeProject("../docker-compose.yaml")
+	if err != nil {
+		t.Fatalf("loadComposeProject: %v", err)
+	}
+
+	if project.Name != normalizeProjectName(filepath.Base(project.WorkingDir)) {
+		t.Errorf("unexpected project name %q", project.Name)
+	}
+	if len(project.Services) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(project.Services))
+	}
+
+	gitea := project.Services["gitea"]
+	if gitea == nil {
+		t.Fatal("service gitea is missing")
+	}
+	if gitea.Image != "gitea/gitea:latest" {
+		t.Errorf("unexpected gitea image %q", gitea.Image)
+	}
+	if gitea.Environment["DB_HOST"] != "db:5432" {
+		t.Errorf("unexpected DB_HOST %q", gitea.Environment["DB_HOST"])
+	}
+	wantPorts := []composePort{{Published: "3000", Target: "3000"}}
+	if !reflect.DeepEqual(gitea.Ports, wantPorts) {
+		t.Errorf("unexpected gitea ports %+v", gitea.Ports)
+	}
+	wantVolumes := []composeServiceVolume{{Type: "volume", Source: "git_data", Target: "/data"}}
+	if !reflect.DeepEqual(gitea.Volumes, wantVolumes) {
+		t.Errorf("unexpected gitea volumes %+v", gitea.Volumes)
+	}
+	if _, ok := gitea.Networks["default"]; !ok {
+		t.Errorf("gitea is not on the default network")
+	}
+
+	db := project.Services["db"]
+	if db == nil {
+		t.Fatal("service db is missing")
+	}
+	if !reflect.DeepEqual(db.Expose, []string{"5432"}) {
+		t.Errorf("unexpected db expose %v", db.Expose)
+	}
+	if project.volumeName("db_data") != project.Name+"_db_data" {
+		t.Errorf("unexpected volume name %q", project.volumeName("db_data"))
+	}
+	if _, err := project.serviceOrder(); err != nil {
+		t.Errorf("serviceOrder: %v", err)
+	}
+}
+
+func TestComposePort(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []PortSpec
+		wantErr bool
+	}{
+		{
+			name: "container port",
+			yaml: `"80"`,
+			want: []PortSpec{{ContainerPort: "80"}},
+		},
+		{
+			name: "host and container port",
+			yaml: `"8080:80/udp"`,
+			want: []PortSpec{{HostPort: "8080", ContainerPort: "80", Protocol: "udp"}},
+		},
+		{
+			name: "host ip",
+			yaml: `"127.0.0.1:8080:80"`,
+			want: []PortSpec{{HostIP: "127.0.0.1", HostPort: "8080", ContainerPort: "80"}},
+		},
+		{
+			name: "ipv6 host ip",
+			yaml: `"[::1]:8080:80"`,
+			want: []PortSpec{{HostIP: "::1", HostPort: "8080", ContainerPort: "80"}},
+		},
+		{
+			name: "ranges",
+			yaml: `"9000-9001:80-81"`,
+			want: []PortSpec{
+				{HostPort: "9000", ContainerPort: "80"},
+				{HostPort: "9001", ContainerPort: "81"},
+			},
+		},
+		{
+			name: "container range without host ports",
+			yaml: `"80-81"`,
+			want: []PortSpec{{ContainerPort: "80"}, {ContainerPort: "81"}},
+		},
+		{
+			name:    "single host port for container range",
+			yaml:    `"9000:80-81"`,
+			wantErr: true,
+		},
+		{
+			name:    "ranges of different length",
+			yaml:    `"9000-9002:80-81"`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid port",
+			yaml:    `"http"`,
+			wantErr: true,
+		},
+		{
+			name: "long syntax",
+			yaml: "{target: 80, published: \"8080\", host_ip: 127.0.0.1, protocol: tcp}",
+			want: []PortSpec{{HostIP: "127.0.0.1", HostPort: "8080", ContainerPort: "80", Protocol: "tcp"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var port composePort
+			if err := yaml.Unmarshal([]byte(tt.yaml), &port); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			got, err := port.expand()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expand: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeServiceVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    composeServiceVolume
+		wantErr bool
+	}{
+		{
+			name: "anonymous volume",
+			yaml: `"/data"`,
+			want: composeServiceVolume{Type: "volume", Target: "/data"},
+		},
+		{
+			name: "named volume",
+			yaml: `"data:/data"`,
+			want: composeServiceVolume{Type: "volume", Source: "data", Target: "/data"},
+		},
+		{
+			name: "read only bind mount",
+			yaml: `"./config:/etc/app:ro,z"`,
+			want: composeServiceVolume{Type: "bind", Source: "./config", Target: "/etc/app", ReadOnly: true},
+		},
+		{
+			name: "absolute bind mount",
+			yaml: `"/srv/app:/app"`,
+			want: composeServiceVolume{Type: "bind", Source: "/srv/app", Target: "/app"},
+		},
+		{
+			name:    "too many fields",
+			yaml:    `"a:b:c:d"`,
+			wantErr: true,
+		},
+		{
+			name: "long syntax defaults to volume",
+			yaml: "{source: data, target: /data, read_only: true}",
+			want: composeServiceVolume{Type: "volume", Source: "data", Target: "/data", ReadOnly: true},
+		},
+		{
+			name: "long syntax bind",
+			yaml: "{type: bind, source: ./src, target: /src}",
+			want: composeServiceVolume{Type: "bind", Source: "./src", Target: "/src"},
+		},
+		{
+			name: "long syntax tmpfs",
+			yaml: "{type: tmpfs, target: /tmp}",
+			want: composeServiceVolume{Type: "tmpfs", Target: "/tmp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got composeServiceVolume
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeTmpfsMount(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "compose.yaml")
+	data := "services:\n  app:\n    image: busybox\n    volumes:\n      - type: tmpfs\n        target: /cache\n"
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := loadComposeProject(file)
+	if err != nil {
+		t.Fatalf("loadComposeProject: %v", err)
+	}
+	spec, err := project.containerSpec("app")
+	if err != nil {
+		t.Fatalf("containerSpec: %v", err)
+	}
+	want := []MountSpec{{Type: "tmpfs", Target: "/cache"}}
+	if !reflect.DeepEqual(spec.Mounts, want) {
+		t.Fatalf("got %+v, want %+v", spec.Mounts, want)
+	}
+	if err := spec.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("COMPOSE_TEST_SET", "set")
+	t.Setenv("COMPOSE_TEST_EMPTY", "")
+	env := map[string]string{
+		"COMPOSE_TEST_DOTENV": "dotenv",
+		"COMPOSE_TEST_SET":    "overridden",
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr string
+	}{
+		{name: "plain", text: "$COMPOSE_TEST_SET", want: "set"},
+		{name: "braces", text: "a-${COMPOSE_TEST_SET}-b", want: "a-set-b"},
+		{name: "dotenv", text: "${COMPOSE_TEST_DOTENV}", want: "dotenv"},
+		{name: "unset", text: "[${COMPOSE_TEST_UNSET}]", want: "[]"},
+		{name: "escaped dollar", text: "$$COMPOSE_TEST_SET", want: "$COMPOSE_TEST_SET"},
+		{name: "default when unset", text: "${COMPOSE_TEST_UNSET:-fallback}", want: "fallback"},
+		{name: "default when empty", text: "${COMPOSE_TEST_EMPTY:-fallback}", want: "fallback"},
+		{name: "default not used", text: "${COMPOSE_TEST_SET:-fallback}", want: "set"},
+		{name: "unset default keeps empty", text: "[${COMPOSE_TEST_EMPTY-fallback}]", want: "[]"},
+		{name: "unset default when unset", text: "${COMPOSE_TEST_UNSET-fallback}", want: "fallback"},
+		{name: "default with dash", text: "${COMPOSE_TEST_UNSET:-a-b}", want: "a-b"},
+		{name: "nested default", text: "${COMPOSE_TEST_UNSET:-${COMPOSE_TEST_DOTENV}}", want: "dotenv"},
+		{name: "deeply nested default", text: "${COMPOSE_TEST_UNSET:-${COMPOSE_TEST_OTHER:-x}-y}", want: "x-y"},
+		{name: "required set", text: "${COMPOSE_TEST_SET:?missing}", want: "set"},
+		{name: "required unset", text: "${COMPOSE_TEST_UNSET:?please set it}", wantErr: "please set it"},
+		{name: "required empty", text: "${COMPOSE_TEST_EMPTY:?empty}", wantErr: "COMPOSE_TEST_EMPTY"},
+		{name: "required allows empty", text: "[${COMPOSE_TEST_EMPTY?missing}]", want: "[]"},
+		{name: "required without colon", text: "${COMPOSE_TEST_UNSET?}", wantErr: "COMPOSE_TEST_UNSET"},
+		{name: "unterminated", text: "${COMPOSE_TEST_SET", wantErr: "unterminated"},
+		{name: "invalid", text: "${:-x}", wantErr: "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := interpolate(tt.text, env)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %q, %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("interpolate: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadComposeProjectInterpolation(t *testing.T) {
+	dir := t.TempDir()
+	dotenv := "PASSWORD=\"p@ss #1\"\nGREETING=\"a: b\"\nTTY=true\nPORT=8080\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(dotenv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data := `# ${COMPOSE_TEST_REQUIRED:?comments are not interpolated}
+services:
+  app:
+    image: busybox:${TAG:-latest}
+    tty: ${TTY}
+    environment:
+      PASSWORD: ${PASSWORD}
+      GREETING: $GREETING
+      LITERAL: "$${PASSWORD}"
+    ports:
+      - "${PORT}:80"
+`
+	file := filepath.Join(dir, "compose.yaml")
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := loadComposeProject(file)
+	if err != nil {
+		t.Fatalf("loadComposeProject: %v", err)
+	}
+	app := project.Services["app"]
+	if app.Image != "busybox:latest" {
+		t.Errorf("unexpected image %q", app.Image)
+	}
+	if !app.Tty {
+		t.Errorf("tty was not resolved to true")
+	}
+	wantEnv := composeMapping{"PASSWORD": "p@ss #1", "GREETING": "a: b", "LITERAL": "${PASSWORD}"}
+	if !reflect.DeepEqual(app.Environment, wantEnv) {
+		t.Errorf("got environment %v, want %v", app.Environment, wantEnv)
+	}
+	wantPorts := []composePort{{Published: "8080", Target: "80"}}
+	if !reflect.DeepEqual(app.Ports, wantPorts) {
+		t.Errorf("got ports %+v, want %+v", app.Ports, wantPorts)
+	}
+
+	data = "services:\n  app:\n    image: ${COMPOSE_TEST_REQUIRED:?set an image}\n"
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadComposeProject(file); err == nil || !strings.Contains(err.Error(), "set an image") {
+		t.Errorf("expected the required variable error, got %v", err)
+	}
+}
+
+func TestServiceOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		dependsOn map[string][]string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "no dependencies",
+			dependsOn: map[string][]string{"b": nil, "a": nil},
+			want:      []string{"a", "b"},
+		},
+		{
+			name:      "chain",
+			dependsOn: map[string][]string{"web": {"api"}, "api": {"db"}, "db": nil},
+			want:      []string{"db", "api", "web"},
+		},
+		{
+			name:      "shared dependency",
+			dependsOn: map[string][]string{"a": {"db"}, "b": {"db"}, "db": nil},
+			want:      []string{"db", "a", "b"},
+		},
+		{
+			name:      "self dependency",
+			dependsOn: map[string][]string{"a": {"a"}},
+			wantErr:   true,
+		},
+		{
+			name:      "cycle",
+			dependsOn: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &composeProject{Services: make(map[string]*composeService)}
+			for name, dependencies := range tt.dependsOn {
+				service := &composeService{DependsOn: make(composeDependsOn)}
+				for _, dependency := range dependencies {
+					service.DependsOn[dependency] = composeDependency{Condition: "service_started"}
+				}
+				project.Services[name] = service
+			}
+
+			got, err := project.serviceOrder()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("serviceOrder: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,11 +21,12 @@ type DockerContainersService struct {
 }
 
 type ContainerInfo struct {
-	ID     string   `json:"id"`
-	Names  []string `json:"names"`
-	Image  string   `json:"image"`
-	Status string   `json:"status"`
-	State  string   `json:"state"`
+	ID       string   `json:"id"`
+	Names    []string `json:"names"`
+	Image    string   `json:"image"`
+	Status   string   `json:"status"`
+	State    string   `json:"state"`
+	Networks []string `json:"networks"`
 }
 
 type ContainersGroup struct {
@@ -75,6 +77,8 @@ func (s *DockerContainersService) StartWatching() error {
 	go func() {
 		eventFilter := filters.NewArgs()
 		eventFilter.Add("type", "container")
+		// Connecting a container to a network is reported as a network event
+		eventFilter.Add("type", "network")
 		eventsChan, errs := s.cli.Events(ctx, events.ListOptions{
 			Filters: eventFilter,
 		})
@@ -82,7 +86,7 @@ func (s *DockerContainersService) StartWatching() error {
 		for {
 			select {
 			case event := <-eventsChan:
-				if event.Type == events.ContainerEventType {
+				if event.Type == events.ContainerEventType || isNetworkMembershipEvent(event) {
 					s.sendListUpdate()
 				}
 			case err := <-errs:
@@ -116,15 +120,22 @@ func (s *DockerContainersService) formatList(containers []container.Summary) []C
 
 	for _, container := range containers {
 		containerInfo := ContainerInfo{
-			ID:     container.ID[:12],
-			Names:  container.Names,
-			Image:  container.Image,
-			Status: container.Status,
-			State:  container.State,
+			ID:       container.ID[:12],
+			Names:    container.Names,
+			Image:    container.Image,
+			Status:   container.Status,
+			State:    container.State,
+			Networks: []string{},
+		}
+		if container.NetworkSettings != nil {
+			for name := range container.NetworkSettings.Networks {
+				containerInfo.Networks = append(containerInfo.Networks, name)
+			}
+			sort.Strings(containerInfo.Networks)
 		}
 
 		// Check for compose project label
-		name := container.Labels["com.docker.compose.project"]
+		name := container.Labels[composeProjectLabel]
 		if name != "" {
 			list[name] = append(list[name], containerInfo)
 		} else {
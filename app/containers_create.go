@@ -0,0 +1,330 @@
+package app
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+var containerNameRegexp = regexp.MustCompile(`^/?[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+type ContainerSpec struct {
+	Name          string            `json:"name"`
+	Image         string            `json:"image"`
+	Cmd           []string          `json:"cmd"`
+	Entrypoint    []string          `json:"entrypoint"`
+	Env           map[string]string `json:"env"`
+	WorkingDir    string            `json:"workingDir"`
+	User          string            `json:"user"`
+	Tty           bool              `json:"tty"`
+	Ports         []PortSpec        `json:"ports"`
+	Mounts        []MountSpec       `json:"mounts"`
+	Networks      []string          `json:"networks"`
+	RestartPolicy string            `json:"restartPolicy"`
+	MaxRetries    int               `json:"maxRetries"`
+	Labels        map[string]string `json:"labels"`
+	Resources     ResourcesSpec     `json:"resources"`
+	Start         bool              `json:"start"`
+}
+
+type PortSpec struct {
+	HostIP        string `json:"hostIp"`
+	HostPort      string `json:"hostPort"`
+	ContainerPort string `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+type MountSpec struct {
+	// Type is one of "bind", "volume" or "tmpfs"
+	Type     string `json:"type"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+type ResourcesSpec struct {
+	CPUs        float64 `json:"cpus"`
+	CPUShares   int64   `json:"cpuShares"`
+	MemoryBytes int64   `json:"memoryBytes"`
+	MemorySwap  int64   `json:"memorySwap"`
+	PidsLimit   int64   `json:"pidsLimit"`
+}
+
+type ContainerCreateResult struct {
+	ID       string   `json:"id"`
+	Warnings []string `json:"warnings"`
+}
+
+func (s *DockerContainersService) Create(spec ContainerSpec) (ContainerCreateResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return ContainerCreateResult{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	if err := spec.Validate(); err != nil {
+		return ContainerCreateResult{}, err
+	}
+
+	config, hostConfig, networkingConfig, err := spec.build()
+	if err != nil {
+		return ContainerCreateResult{}, err
+	}
+
+	resp, err := s.createContainer(config, hostConfig, networkingConfig, spec.Name)
+	if err != nil {
+		return ContainerCreateResult{}, err
+	}
+
+	result := ContainerCreateResult{
+		ID:       resp.ID,
+		Warnings: resp.Warnings,
+	}
+	if result.Warnings == nil {
+		result.Warnings = []string{}
+	}
+
+	if spec.Start {
+		// The caller only gets the error, so the container it cannot refer
+		// to is not left behind
+		if err := s.cli.ContainerStart(s.ctx, resp.ID, container.StartOptions{}); err != nil {
+			s.cli.ContainerRemove(s.ctx, resp.ID, container.RemoveOptions{Force: true})
+			return ContainerCreateResult{}, fmt.Errorf("failed to start container: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (spec ContainerSpec) Validate() error {
+	if strings.TrimSpace(spec.Image) == "" {
+		return fmt.Errorf("image is required")
+	}
+	if spec.Name != "" && !containerNameRegexp.MatchString(spec.Name) {
+		return fmt.Errorf("invalid container name %q", spec.Name)
+	}
+	for key := range spec.Env {
+		if key == "" || strings.Contains(key, "=") {
+			return fmt.Errorf("invalid environment variable name %q", key)
+		}
+	}
+	for _, port := range spec.Ports {
+		if err := port.validate(); err != nil {
+			return err
+		}
+	}
+	for _, m := range spec.Mounts {
+		if err := m.validate(); err != nil {
+			return err
+		}
+	}
+	seen := make(map[string]bool, len(spec.Networks))
+	for _, name := range spec.Networks {
+		if !networkNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid network name %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("network %s is listed twice", name)
+		}
+		seen[name] = true
+	}
+	if err := container.ValidateRestartPolicy(spec.restartPolicy()); err != nil {
+		return err
+	}
+	if spec.Resources.CPUs < 0 || spec.Resources.CPUShares < 0 || spec.Resources.MemoryBytes < 0 {
+		return fmt.Errorf("resource limits must not be negative")
+	}
+	if spec.Resources.MemorySwap > 0 && spec.Resources.MemorySwap < spec.Resources.MemoryBytes {
+		return fmt.Errorf("memory swap limit must be larger than memory limit")
+	}
+	return nil
+}
+
+func (port PortSpec) validate() error {
+	if _, err := parsePortNumber(port.ContainerPort); err != nil {
+		return fmt.Errorf("invalid container port %q", port.ContainerPort)
+	}
+	if port.HostPort != "" {
+		if _, err := parsePortNumber(port.HostPort); err != nil {
+			return fmt.Errorf("invalid host port %q", port.HostPort)
+		}
+	}
+	switch port.protocol() {
+	case "tcp", "udp", "sctp":
+	default:
+		return fmt.Errorf("invalid protocol %q for port %s", port.Protocol, port.ContainerPort)
+	}
+	return nil
+}
+
+func (port PortSpec) protocol() string {
+	if port.Protocol == "" {
+		return "tcp"
+	}
+	return strings.ToLower(port.Protocol)
+}
+
+func (m MountSpec) validate() error {
+	if m.Target == "" || !path.IsAbs(m.Target) {
+		return fmt.Errorf("mount target %q must be an absolute path", m.Target)
+	}
+	switch mount.Type(m.Type) {
+	case mount.TypeBind:
+		if m.Source == "" {
+			return fmt.Errorf("bind mount for %s requires a host path", m.Target)
+		}
+	case mount.TypeVolume:
+	case mount.TypeTmpfs:
+		if m.Source != "" {
+			return fmt.Errorf("tmpfs mount for %s does not take a source", m.Target)
+		}
+	default:
+		return fmt.Errorf("unsupported mount type %q", m.Type)
+	}
+	return nil
+}
+
+func (spec ContainerSpec) restartPolicy() container.RestartPolicy {
+	policy := container.RestartPolicy{Name: container.RestartPolicyMode(spec.RestartPolicy)}
+	if policy.Name == "" {
+		policy.Name = container.RestartPolicyDisabled
+	}
+	if policy.IsOnFailure() {
+		policy.MaximumRetryCount = spec.MaxRetries
+	}
+	return policy
+}
+
+func (spec ContainerSpec) build() (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	config := &container.Config{
+		Image:        spec.Image,
+		Cmd:          spec.Cmd,
+		Entrypoint:   spec.Entrypoint,
+		Env:          formatEnv(spec.Env),
+		WorkingDir:   spec.WorkingDir,
+		User:         spec.User,
+		Tty:          spec.Tty,
+		Labels:       spec.Labels,
+		ExposedPorts: nat.PortSet{},
+	}
+
+	hostConfig := &container.HostConfig{
+		PortBindings:  nat.PortMap{},
+		RestartPolicy: spec.restartPolicy(),
+		Resources: container.Resources{
+			NanoCPUs:   int64(spec.Resources.CPUs * 1e9),
+			CPUShares:  spec.Resources.CPUShares,
+			Memory:     spec.Resources.MemoryBytes,
+			MemorySwap: spec.Resources.MemorySwap,
+		},
+	}
+	if spec.Resources.PidsLimit > 0 {
+		pidsLimit := spec.Resources.PidsLimit
+		hostConfig.Resources.PidsLimit = &pidsLimit
+	}
+
+	for _, port := range spec.Ports {
+		containerPort, err := nat.NewPort(port.protocol(), port.ContainerPort)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid container port %q: %v", port.ContainerPort, err)
+		}
+		config.ExposedPorts[containerPort] = struct{}{}
+		hostConfig.PortBindings[containerPort] = append(hostConfig.PortBindings[containerPort], nat.PortBinding{
+			HostIP:   port.HostIP,
+			HostPort: port.HostPort,
+		})
+	}
+
+	for _, m := range spec.Mounts {
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:     mount.Type(m.Type),
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+
+	var networkingConfig *network.NetworkingConfig
+	if len(spec.Networks) > 0 {
+		hostConfig.NetworkMode = container.NetworkMode(spec.Networks[0])
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: make(map[string]*network.EndpointSettings, len(spec.Networks)),
+		}
+		for _, name := range spec.Networks {
+			networkingConfig.EndpointsConfig[name] = &network.EndpointSettings{}
+		}
+	}
+
+	return config, hostConfig, networkingConfig, nil
+}
+
+// createContainer creates a container attached to the network of
+// hostConfig.NetworkMode and connects it to the other networks of
+// networkingConfig afterwards, as daemons before API 1.44 accept only one
+// endpoint on create. The container is removed again when that fails.
+func (s *DockerBaseService) createContainer(config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, name string) (container.CreateResponse, error) {
+	var others map[string]*network.EndpointSettings
+	if networkingConfig != nil && len(networkingConfig.EndpointsConfig) > 1 {
+		first := string(hostConfig.NetworkMode)
+		others = make(map[string]*network.EndpointSettings, len(networkingConfig.EndpointsConfig)-1)
+		for key, endpoint := range networkingConfig.EndpointsConfig {
+			if key != first {
+				others[key] = endpoint
+			}
+		}
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				first: networkingConfig.EndpointsConfig[first],
+			},
+		}
+	}
+
+	resp, err := s.cli.ContainerCreate(s.ctx, config, hostConfig, networkingConfig, nil, name)
+	if err != nil {
+		return resp, fmt.Errorf("failed to create container: %v", err)
+	}
+
+	keys := make([]string, 0, len(others))
+	for key := range others {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := s.cli.NetworkConnect(s.ctx, key, resp.ID, others[key]); err != nil {
+			s.cli.ContainerRemove(s.ctx, resp.ID, container.RemoveOptions{Force: true})
+			return container.CreateResponse{}, fmt.Errorf("failed to connect container to network %s: %v", key, err)
+		}
+	}
+	return resp, nil
+}
+
+func formatEnv(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, key+"="+env[key])
+	}
+	return result
+}
+
+func parsePortNumber(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d out of range", port)
+	}
+	return port, nil
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const (
+	composeProjectLabel   = "com.docker.compose.project"
+	composeServiceLabel   = "com.docker.compose.service"
+	composeDependsOnLabel = "com.docker.compose.depends_on"
+)
+
+var errNoProjectContainers = errors.New("no containers found")
+
+type ContainerActionResult struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Service string `json:"service"`
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// groupAction runs on a single container of a group. Dependents of a
+// container whose action failed are skipped when skipDependents is set.
+type groupAction struct {
+	run            func(ctx context.Context, c container.Summary) error
+	reverse        bool
+	skipDependents bool
+}
+
+func (s *DockerContainersService) StartGroup(name string) ([]ContainerActionResult, error) {
+	return s.runGroupAction(name, groupAction{
+		run: func(ctx context.Context, c container.Summary) error {
+			return s.cli.ContainerStart(ctx, c.ID, container.StartOptions{})
+		},
+		skipDependents: true,
+	})
+}
+
+func (s *DockerContainersService) StopGroup(name string) ([]ContainerActionResult, error) {
+	return s.runGroupAction(name, groupAction{
+		run: func(ctx context.Context, c container.Summary) error {
+			return s.cli.ContainerStop(ctx, c.ID, container.StopOptions{})
+		},
+		reverse: true,
+	})
+}
+
+func (s *DockerContainersService) RestartGroup(name string) ([]ContainerActionResult, error) {
+	return s.runGroupAction(name, groupAction{
+		run: func(ctx context.Context, c container.Summary) error {
+			return s.cli.ContainerRestart(ctx, c.ID, container.StopOptions{})
+		},
+		skipDependents: true,
+	})
+}
+
+func (s *DockerContainersService) RemoveGroup(name string) ([]ContainerActionResult, error) {
+	return s.runGroupAction(name, groupAction{
+		run: func(ctx context.Context, c container.Summary) error {
+			if c.State == container.StateRunning || c.State == container.StatePaused {
+				if err := s.cli.ContainerStop(ctx, c.ID, container.StopOptions{}); err != nil {
+					return err
+				}
+			}
+			return s.cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{})
+		},
+		reverse: true,
+	})
+}
+
+func (s *DockerBaseService) runGroupAction(name string, action groupAction) ([]ContainerActionResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+
+	filter := filters.NewArgs()
+	filter.Add("label", composeProjectLabel+"="+name)
+	list, err := s.cli.ContainerList(s.ctx, container.ListOptions{All: true, Filters: filter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%w for project %q", errNoProjectContainers, name)
+	}
+
+	ordered := orderByDependencies(list)
+	if action.reverse {
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	}
+
+	failed := make(map[string]string)
+	results := make([]ContainerActionResult, 0, len(ordered))
+	for _, c := range ordered {
+		service := c.Labels[composeServiceLabel]
+		result := ContainerActionResult{
+			ID:      c.ID[:12],
+			Name:    containerName(c),
+			Service: service,
+		}
+
+		if dependency := failedDependency(c, failed); action.skipDependents && dependency != "" {
+			result.Error = fmt.Sprintf("skipped: dependency %q failed", dependency)
+			failed[service] = result.Error
+			results = append(results, result)
+			continue
+		}
+
+		if err := action.run(s.ctx, c); err != nil {
+			result.Error = err.Error()
+			failed[service] = result.Error
+		} else {
+			result.Success = true
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// orderByDependencies sorts containers so that services come after the
+// services they depend on. Cycles are broken by service name.
+func orderByDependencies(list []container.Summary) []container.Summary {
+	byService := make(map[string][]container.Summary)
+	for _, c := range list {
+		service := c.Labels[composeServiceLabel]
+		byService[service] = append(byService[service], c)
+	}
+
+	services := make([]string, 0, len(byService))
+	for service := range byService {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+	ordered := make([]container.Summary, 0, len(list))
+
+	var visit func(service string)
+	visit = func(service string) {
+		if visited[service] || visiting[service] {
+			return
+		}
+		visiting[service] = true
+		containers := byService[service]
+		if len(containers) > 0 {
+			for _, dependency := range parseDependsOn(containers[0].Labels[composeDependsOnLabel]) {
+				if _, ok := byService[dependency]; ok {
+					visit(dependency)
+				}
+			}
+		}
+		visiting[service] = false
+		visited[service] = true
+		ordered = append(ordered, containers...)
+	}
+
+	for _, service := range services {
+		visit(service)
+	}
+	return ordered
+}
+
+// parseDependsOn reads the service names from a depends_on label in the
+// "service:condition:restart,..." format written by docker compose.
+func parseDependsOn(label string) []string {
+	var services []string
+	for _, entry := range strings.Split(label, ",") {
+		service, _, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		if service != "" {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+func failedDependency(c container.Summary, failed map[string]string) string {
+	for _, dependency := range parseDependsOn(c.Labels[composeDependsOnLabel]) {
+		if _, ok := failed[dependency]; ok {
+			return dependency
+		}
+	}
+	return ""
+}
+
+func containerName(c container.Summary) string {
+	if len(c.Names) == 0 {
+		return c.ID[:12]
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
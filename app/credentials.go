@@ -0,0 +1,355 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// defaultRegistry is the key docker uses for Docker Hub credentials.
+const defaultRegistry = "https://index.docker.io/v1/"
+
+// tokenUsername marks identity tokens returned by credential helpers.
+const tokenUsername = "<token>"
+
+// dockerConfig is the subset of ~/.docker/config.json that holds registry
+// credentials. Unknown fields are preserved when the file is written back.
+type dockerConfig struct {
+	path        string
+	raw         map[string]json.RawMessage
+	Auths       map[string]dockerConfigAuth
+	CredsStore  string
+	CredHelpers map[string]string
+}
+
+type dockerConfigAuth struct {
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+type credentialHelperEntry struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func dockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+func loadDockerConfig() (*dockerConfig, error) {
+	path, err := dockerConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &dockerConfig{
+		path:        path,
+		raw:         make(map[string]json.RawMessage),
+		Auths:       make(map[string]dockerConfigAuth),
+		CredHelpers: make(map[string]string),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read docker config: %v", err)
+	}
+	if err := json.Unmarshal(data, &cfg.raw); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config: %v", err)
+	}
+
+	fields := map[string]any{
+		"auths":       &cfg.Auths,
+		"credsStore":  &cfg.CredsStore,
+		"credHelpers": &cfg.CredHelpers,
+	}
+	for key, target := range fields {
+		if value, ok := cfg.raw[key]; ok {
+			if err := json.Unmarshal(value, target); err != nil {
+				return nil, fmt.Errorf("failed to parse %q in docker config: %v", key, err)
+			}
+		}
+	}
+	if cfg.Auths == nil {
+		cfg.Auths = make(map[string]dockerConfigAuth)
+	}
+	if cfg.CredHelpers == nil {
+		cfg.CredHelpers = make(map[string]string)
+	}
+
+	return cfg, nil
+}
+
+func (c *dockerConfig) save() error {
+	auths, err := json.Marshal(c.Auths)
+	if err != nil {
+		return err
+	}
+	c.raw["auths"] = auths
+
+	data, err := json.MarshalIndent(c.raw, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// helper returns the credential helper responsible for server, or "" when
+// credentials are stored in the config file itself.
+func (c *dockerConfig) helper(server string) string {
+	if helper, ok := c.CredHelpers[registryHostname(server)]; ok {
+		return helper
+	}
+	return c.CredsStore
+}
+
+func (c *dockerConfig) get(server string) (registry.AuthConfig, error) {
+	auth := registry.AuthConfig{ServerAddress: server}
+
+	if helper := c.helper(server); helper != "" {
+		out, err := runCredentialHelper(helper, "get", []byte(server))
+		if err != nil {
+			if isCredentialsNotFound(err) {
+				return auth, nil
+			}
+			return auth, err
+		}
+		var entry credentialHelperEntry
+		if err := json.Unmarshal(out, &entry); err != nil {
+			return auth, fmt.Errorf("invalid credential helper output: %v", err)
+		}
+		if entry.Username == tokenUsername {
+			auth.IdentityToken = entry.Secret
+		} else {
+			auth.Username, auth.Password = entry.Username, entry.Secret
+		}
+		return auth, nil
+	}
+
+	entry, ok := c.Auths[server]
+	if !ok {
+		hostname := registryHostname(server)
+		for key, value := range c.Auths {
+			if registryHostname(key) == hostname {
+				entry, ok = value, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return auth, nil
+	}
+
+	auth.IdentityToken = entry.IdentityToken
+	if entry.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return auth, fmt.Errorf("invalid auth for %s: %v", server, err)
+		}
+		username, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return auth, fmt.Errorf("invalid auth for %s", server)
+		}
+		auth.Username, auth.Password = username, password
+	}
+	return auth, nil
+}
+
+func (c *dockerConfig) store(auth registry.AuthConfig) error {
+	server := auth.ServerAddress
+
+	if helper := c.helper(server); helper != "" {
+		entry := credentialHelperEntry{
+			ServerURL: server,
+			Username:  auth.Username,
+			Secret:    auth.Password,
+		}
+		if auth.IdentityToken != "" {
+			entry.Username, entry.Secret = tokenUsername, auth.IdentityToken
+		}
+		input, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := runCredentialHelper(helper, "store", input); err != nil {
+			return err
+		}
+		// docker keeps an empty entry so that the login shows up in the file
+		c.Auths[server] = dockerConfigAuth{}
+		return c.save()
+	}
+
+	// Like docker login the username is kept next to an identity token, only
+	// the password is left out
+	entry := dockerConfigAuth{IdentityToken: auth.IdentityToken}
+	if auth.IdentityToken == "" {
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+	} else {
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(auth.Username + ":"))
+	}
+	c.Auths[server] = entry
+	return c.save()
+}
+
+func (c *dockerConfig) erase(server string) error {
+	if helper := c.helper(server); helper != "" {
+		if _, err := runCredentialHelper(helper, "erase", []byte(server)); err != nil && !isCredentialsNotFound(err) {
+			return err
+		}
+	}
+
+	hostname := registryHostname(server)
+	for key := range c.Auths {
+		if key == server || registryHostname(key) == hostname {
+			delete(c.Auths, key)
+		}
+	}
+	return c.save()
+}
+
+// servers lists every registry with stored credentials.
+func (c *dockerConfig) servers() []string {
+	seen := make(map[string]bool)
+	for key := range c.Auths {
+		seen[key] = true
+	}
+	if c.CredsStore != "" {
+		if out, err := runCredentialHelper(c.CredsStore, "list", nil); err == nil {
+			var listed map[string]string
+			if json.Unmarshal(out, &listed) == nil {
+				for key := range listed {
+					seen[key] = true
+				}
+			}
+		}
+	}
+
+	servers := make([]string, 0, len(seen))
+	for key := range seen {
+		servers = append(servers, key)
+	}
+	sort.Strings(servers)
+	return servers
+}
+
+type credentialHelperError struct {
+	message string
+}
+
+func (e credentialHelperError) Error() string {
+	return e.message
+}
+
+func runCredentialHelper(helper string, action string, input []byte) ([]byte, error) {
+	cmd := exec.Command("docker-credential-"+helper, action)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stdout.String())
+		if message == "" {
+			message = strings.TrimSpace(stderr.String())
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, credentialHelperError{message: fmt.Sprintf("docker-credential-%s %s: %s", helper, action, message)}
+	}
+	return stdout.Bytes(), nil
+}
+
+func isCredentialsNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "credentials not found")
+}
+
+// registryHostname strips the scheme and path from a registry address so
+// that "https://index.docker.io/v1/" and "index.docker.io" compare equal.
+func registryHostname(server string) string {
+	server = strings.TrimPrefix(server, "https://")
+	server = strings.TrimPrefix(server, "http://")
+	host, _, _ := strings.Cut(server, "/")
+	return host
+}
+
+// registryForRef returns the credentials key for the registry an image
+// reference points to.
+func registryForRef(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %v", ref, err)
+	}
+	return registryForDomain(reference.Domain(named)), nil
+}
+
+func registryForDomain(domain string) string {
+	if domain == "" || domain == "docker.io" || domain == "index.docker.io" || domain == "registry-1.docker.io" {
+		return defaultRegistry
+	}
+	return domain
+}
+
+// encodedRegistryAuth returns the X-Registry-Auth header value for server.
+// An empty AuthConfig is encoded when no credentials are stored, as the
+// daemon expects the header to be present. A config file or credential
+// helper that cannot be read is an error, pulling anonymously instead would
+// only show up as an unrelated authorization failure.
+func encodedRegistryAuth(server string) (string, error) {
+	cfg, err := loadDockerConfig()
+	if err != nil {
+		return "", err
+	}
+	auth, err := cfg.get(server)
+	if err != nil {
+		return "", fmt.Errorf("failed to get credentials for %s: %v", server, err)
+	}
+	return registry.EncodeAuthConfig(auth)
+}
+
+func encodedRegistryAuthForRef(ref string) (string, error) {
+	server, err := registryForRef(ref)
+	if err != nil {
+		return "", err
+	}
+	return encodedRegistryAuth(server)
+}
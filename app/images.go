@@ -1,8 +1,10 @@
 package app
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -10,18 +12,27 @@ import (
 	"sync"
 	"time"
 
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/distribution/reference"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type DockerImagesService struct {
 	DockerBaseService
-	cancel context.CancelFunc
-	mu     sync.Mutex
+	cancel    context.CancelFunc
+	mu        sync.Mutex
+
+	// transfers has its own lock so that starting one, which waits for
+	// the daemon, does not block the watcher or other transfers
+	transfers   map[string]*imageTransfer
+	transfersMu sync.Mutex
 }
 
 type ImageInfo struct {
@@ -136,12 +147,211 @@ func (s *DockerImagesService) sendListUpdate() {
 	runtime.EventsEmit(s.ctx, "docker:images", result)
 }
 
-func (s *DockerImagesService) Remove(id string) error {
+type ImageDependent struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	State string `json:"state"`
+}
+
+type ImageRemoveResult struct {
+	Removed   bool             `json:"removed"`
+	Conflicts []ImageDependent `json:"conflicts"`
+	Deleted   []string         `json:"deleted"`
+	Untagged  []string         `json:"untagged"`
+}
+
+// Remove deletes an image. Unless force is set, nothing is removed while
+// containers, running or stopped, still use the image; they are returned as
+// conflicts instead.
+func (s *DockerImagesService) Remove(id string, force bool) (ImageRemoveResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return ImageRemoveResult{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	result := ImageRemoveResult{
+		Conflicts: []ImageDependent{},
+		Deleted:   []string{},
+		Untagged:  []string{},
+	}
+
+	if !force {
+		dependents, err := s.dependents(id)
+		if err != nil {
+			return result, err
+		}
+		if len(dependents) > 0 {
+			result.Conflicts = dependents
+			return result, nil
+		}
+	}
+
+	// Without force the daemon refuses to remove an image with several tags
+	// by its ID, which is fine to do once no container uses it
+	items, err := s.cli.ImageRemove(s.ctx, id, image.RemoveOptions{Force: true, PruneChildren: true})
+	if err != nil {
+		return result, fmt.Errorf("failed to remove image: %v", err)
+	}
+	for _, item := range items {
+		if item.Deleted != "" {
+			result.Deleted = append(result.Deleted, strings.TrimPrefix(item.Deleted, "sha256:"))
+		}
+		if item.Untagged != "" {
+			result.Untagged = append(result.Untagged, item.Untagged)
+		}
+	}
+	result.Removed = true
+	return result, nil
+}
+
+// dependents lists the containers created from the given image.
+func (s *DockerImagesService) dependents(id string) ([]ImageDependent, error) {
+	inspect, err := s.cli.ImageInspect(s.ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image data: %v", err)
+	}
+	containers, err := s.cli.ContainerList(s.ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	dependents := []ImageDependent{}
+	for _, c := range containers {
+		if c.ImageID != inspect.ID {
+			continue
+		}
+		dependents = append(dependents, ImageDependent{
+			ID:    c.ID[:12],
+			Name:  containerName(c),
+			Image: c.Image,
+			State: string(c.State),
+		})
+	}
+	return dependents, nil
+}
+
+func (s *DockerImagesService) Tag(source string, target string) error {
 	if s.cli == nil || s.ctx == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	_, err := s.cli.ImageRemove(s.ctx, id, image.RemoveOptions{Force: true})
-	return err
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("target tag is required")
+	}
+	if err := s.cli.ImageTag(s.ctx, source, target); err != nil {
+		return fmt.Errorf("failed to tag image: %v", err)
+	}
+	return nil
+}
+
+// Retag moves a tag to a new name. The image itself stays in place as the
+// new tag still references it.
+func (s *DockerImagesService) Retag(oldRef string, newRef string) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+
+	oldTag, err := parseImageTag(oldRef)
+	if err != nil {
+		return err
+	}
+	newTag, err := parseImageTag(newRef)
+	if err != nil {
+		return err
+	}
+	if oldTag == newTag {
+		return fmt.Errorf("old and new tag are the same")
+	}
+
+	// oldRef has to be one of the tags of the image, an image ID would
+	// remove the whole image below
+	inspect, err := s.cli.ImageInspect(s.ctx, oldTag)
+	if err != nil {
+		return fmt.Errorf("failed to get image data: %v", err)
+	}
+	tagged := false
+	for _, repoTag := range inspect.RepoTags {
+		if tag, err := parseImageTag(repoTag); err == nil && tag == oldTag {
+			tagged = true
+			break
+		}
+	}
+	if !tagged {
+		return fmt.Errorf("%s is not a tag", oldRef)
+	}
+	if _, err := s.cli.ImageInspect(s.ctx, newTag); err == nil {
+		return fmt.Errorf("tag %s already exists", newRef)
+	} else if !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to get image data: %v", err)
+	}
+
+	if err := s.Tag(oldTag, newTag); err != nil {
+		return err
+	}
+	if _, err := s.cli.ImageRemove(s.ctx, oldTag, image.RemoveOptions{}); err != nil {
+		s.cli.ImageRemove(s.ctx, newTag, image.RemoveOptions{})
+		return fmt.Errorf("failed to remove old tag: %v", err)
+	}
+	return nil
+}
+
+// parseImageTag normalizes ref to its familiar name:tag form, adding the
+// latest tag when there is none. References with a digest are refused.
+func parseImageTag(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(ref))
+	if err != nil {
+		return "", fmt.Errorf("invalid image tag %q: %v", ref, err)
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return "", fmt.Errorf("%s is not a tag", ref)
+	}
+	return reference.FamiliarString(reference.TagNameOnly(named)), nil
+}
+
+type ImageSearchResult struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Stars       int    `json:"stars"`
+	Official    bool   `json:"official"`
+}
+
+func (s *DockerImagesService) Search(term string, limit int) ([]ImageSearchResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("search term is required")
+	}
+
+	// A term such as "registry.example.com/app" searches that registry
+	server := defaultRegistry
+	if domain, _, ok := strings.Cut(term, "/"); ok && strings.ContainsAny(domain, ".:") {
+		server = registryForDomain(domain)
+	}
+	auth, err := encodedRegistryAuth(server)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.cli.ImageSearch(s.ctx, term, registry.SearchOptions{
+		RegistryAuth: auth,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search images: %v", err)
+	}
+
+	list := make([]ImageSearchResult, 0, len(results))
+	for _, result := range results {
+		list = append(list, ImageSearchResult{
+			Name:        result.Name,
+			Description: result.Description,
+			Stars:       result.StarCount,
+			Official:    result.IsOfficial,
+		})
+	}
+	return list, nil
 }
 
 func (s *DockerImagesService) Inspect(id string) (string, error) {
@@ -199,6 +409,73 @@ func (s *DockerImagesService) Save(id string) error {
 	return nil
 }
 
+func (s *DockerImagesService) Load() ([]string, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	openPath, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+		Title:   "Load Docker Image",
+		Filters: []runtime.FileFilter{{DisplayName: "Image Archives", Pattern: "*.tar;*.tar.gz;*.tgz"}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dialog error: %w", err)
+	}
+	if openPath == "" {
+		return nil, fmt.Errorf("no file selected")
+	}
+
+	inFile, err := os.Open(openPath)
+	if err != nil {
+		return nil, err
+	}
+	defer inFile.Close()
+
+	// Archives written by Save are gzip-compressed, plain tarballs come from
+	// docker save
+	buffered := bufio.NewReader(inFile)
+	var input io.Reader = buffered
+	magic, err := buffered.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip archive: %v", err)
+		}
+		defer gzipReader.Close()
+		input = gzipReader
+	}
+
+	resp, err := s.cli.ImageLoad(s.ctx, input, client.ImageLoadWithQuiet(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image: %v", err)
+	}
+	defer resp.Body.Close()
+
+	loaded := []string{}
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return loaded, err
+		}
+		if msg.Error != nil {
+			return loaded, fmt.Errorf("%s", msg.Error.Message)
+		}
+		for _, line := range strings.Split(msg.Stream, "\n") {
+			if ref, ok := strings.CutPrefix(line, "Loaded image: "); ok {
+				loaded = append(loaded, strings.TrimSpace(ref))
+			} else if id, ok := strings.CutPrefix(line, "Loaded image ID: "); ok {
+				loaded = append(loaded, strings.TrimPrefix(strings.TrimSpace(id), "sha256:"))
+			}
+		}
+	}
+
+	return loaded, nil
+}
+
 func (s *DockerImagesService) CreateAndStart(id string) error {
 	if s.cli == nil || s.ctx == nil {
 		return fmt.Errorf("Docker client not initialized")
@@ -0,0 +1,310 @@
+package app
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// externalDockerfileName is used inside the build context for a Dockerfile
+// that lives outside of the context directory.
+const externalDockerfileName = ".dockmate.Dockerfile"
+
+type ImageBuildSpec struct {
+	ContextDir string            `json:"contextDir"`
+	Dockerfile string            `json:"dockerfile"`
+	Tags       []string          `json:"tags"`
+	BuildArgs  map[string]string `json:"buildArgs"`
+	Target     string            `json:"target"`
+	NoCache    bool              `json:"noCache"`
+	Pull       bool              `json:"pull"`
+}
+
+type BuildOutput struct {
+	ContextDir string `json:"contextDir"`
+	Stream     string `json:"stream"`
+	ImageID    string `json:"imageId"`
+	Done       bool   `json:"done"`
+	Error      string `json:"error"`
+}
+
+func (s *DockerImagesService) SelectBuildContext() (string, error) {
+	if s.ctx == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+
+	dir, err := runtime.OpenDirectoryDialog(s.ctx, runtime.OpenDialogOptions{
+		Title: "Select Build Context",
+	})
+	if err != nil {
+		return "", fmt.Errorf("dialog error: %w", err)
+	}
+	if dir == "" {
+		return "", fmt.Errorf("no directory selected")
+	}
+	return dir, nil
+}
+
+// Build builds an image from a context directory in the background. The
+// build output is emitted as "docker:images:build" events, the last one has
+// Done set.
+func (s *DockerImagesService) Build(spec ImageBuildSpec) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+
+	contextDir, err := filepath.Abs(spec.ContextDir)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(contextDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("build context %q is not a directory", spec.ContextDir)
+	}
+
+	dockerfile := spec.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	if !filepath.IsAbs(dockerfile) {
+		dockerfile = filepath.Join(contextDir, dockerfile)
+	}
+	if _, err := os.Stat(dockerfile); err != nil {
+		return fmt.Errorf("Dockerfile not found: %v", err)
+	}
+
+	buildArgs := make(map[string]*string, len(spec.BuildArgs))
+	for key, value := range spec.BuildArgs {
+		buildArgs[key] = &value
+	}
+
+	options := build.ImageBuildOptions{
+		Tags:        spec.Tags,
+		BuildArgs:   buildArgs,
+		Target:      spec.Target,
+		NoCache:     spec.NoCache,
+		PullParent:  spec.Pull,
+		Remove:      true,
+		ForceRemove: true,
+	}
+
+	return s.startTransfer("build:"+contextDir, func(ctx context.Context) (io.ReadCloser, error) {
+		buildContext, dockerfileName, err := archiveBuildContext(contextDir, dockerfile)
+		if err != nil {
+			return nil, err
+		}
+		options.Dockerfile = dockerfileName
+
+		resp, err := s.cli.ImageBuild(ctx, buildContext, options)
+		if err != nil {
+			buildContext.Close()
+			return nil, fmt.Errorf("failed to build image: %v", err)
+		}
+		return resp.Body, nil
+	}, func(ctx context.Context, reader io.Reader) {
+		output := BuildOutput{ContextDir: contextDir}
+		err := decodeBuildOutput(reader, func(stream string, imageID string) {
+			if imageID != "" {
+				output.ImageID = imageID
+			}
+			runtime.EventsEmit(s.ctx, "docker:images:build", BuildOutput{
+				ContextDir: contextDir,
+				Stream:     stream,
+				ImageID:    imageID,
+			})
+		})
+		if ctx.Err() != nil {
+			err = fmt.Errorf("cancelled")
+		}
+
+		output.Done = true
+		if err != nil {
+			output.Error = err.Error()
+		}
+		runtime.EventsEmit(s.ctx, "docker:images:build", output)
+	})
+}
+
+func (s *DockerImagesService) CancelBuild(contextDir string) {
+	if dir, err := filepath.Abs(contextDir); err == nil {
+		contextDir = dir
+	}
+	s.cancelTransfer("build:" + contextDir)
+}
+
+// decodeBuildOutput passes the build log lines and the resulting image ID to
+// emit until the stream ends or reports an error.
+func decodeBuildOutput(reader io.Reader, emit func(stream string, imageID string)) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("%s", msg.Error.Message)
+		}
+		if msg.ErrorMessage != "" {
+			return fmt.Errorf("%s", msg.ErrorMessage)
+		}
+
+		var imageID string
+		if msg.Aux != nil {
+			var aux struct {
+				ID string `json:"ID"`
+			}
+			if err := json.Unmarshal(*msg.Aux, &aux); err == nil {
+				imageID = strings.TrimPrefix(aux.ID, "sha256:")
+			}
+		}
+
+		stream := msg.Stream
+		if stream == "" && msg.Status != "" {
+			stream = msg.Status + "\n"
+		}
+		if stream != "" || imageID != "" {
+			emit(stream, imageID)
+		}
+	}
+}
+
+// archiveBuildContext streams contextDir as a tar archive, leaving out the
+// files matched by .dockerignore. It returns the archive and the name of the
+// Dockerfile inside of it.
+func archiveBuildContext(contextDir string, dockerfile string) (io.ReadCloser, string, error) {
+	excludes, err := readDockerignore(contextDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dockerfileName, err := filepath.Rel(contextDir, dockerfile)
+	external := err != nil || strings.HasPrefix(dockerfileName, "..")
+	if external {
+		dockerfileName = externalDockerfileName
+	}
+	dockerfileName = filepath.ToSlash(dockerfileName)
+
+	matcher, err := patternmatcher.New(excludes)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid .dockerignore: %v", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := filepath.WalkDir(contextDir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(contextDir, path)
+			if err != nil || rel == "." {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+
+			// The Dockerfile and .dockerignore are always sent, as the daemon
+			// needs them even when they are ignored.
+			if rel == dockerfileName || rel == ".dockerignore" {
+				return addToTar(tw, path, rel)
+			}
+
+			skip, err := matcher.MatchesOrParentMatches(rel)
+			if err != nil {
+				return err
+			}
+			if skip {
+				// Ignored directories are still walked when they hold the
+				// Dockerfile, so that it is found further down
+				if entry.IsDir() && !matcher.Exclusions() && !strings.HasPrefix(dockerfileName, rel+"/") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			return addToTar(tw, path, rel)
+		})
+		if err == nil && external {
+			err = addToTar(tw, dockerfile, externalDockerfileName)
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return pr, dockerfileName, nil
+}
+
+func readDockerignore(contextDir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(contextDir, ".dockerignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	excludes, err := ignorefile.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .dockerignore: %v", err)
+	}
+	return excludes, nil
+}
+
+func addToTar(tw *tar.Writer, path string, name string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&(os.ModeSocket|os.ModeNamedPipe|os.ModeDevice) != 0 {
+		return nil
+	}
+
+	var link string
+	if info.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tw, file)
+	return err
+}
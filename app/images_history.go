@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	nopInstructionRegexp = regexp.MustCompile(`#\(nop\)\s+([A-Z]+)`)
+	runArgsPrefixRegexp  = regexp.MustCompile(`^\|\d+\s+(\S+=\S*\s+)*`)
+)
+
+var dockerfileInstructions = map[string]bool{
+	"ADD": true, "ARG": true, "CMD": true, "COPY": true, "ENTRYPOINT": true,
+	"ENV": true, "EXPOSE": true, "HEALTHCHECK": true, "LABEL": true,
+	"MAINTAINER": true, "ONBUILD": true, "RUN": true, "SHELL": true,
+	"STOPSIGNAL": true, "USER": true, "VOLUME": true, "WORKDIR": true,
+}
+
+type ImageHistory struct {
+	ID           string               `json:"id"`
+	Size         int64                `json:"size"`
+	Layers       []ImageLayer         `json:"layers"`
+	Instructions []InstructionSummary `json:"instructions"`
+	Largest      []ImageLayer         `json:"largest"`
+}
+
+type ImageLayer struct {
+	ID          string   `json:"id"`
+	Instruction string   `json:"instruction"`
+	CreatedBy   string   `json:"createdBy"`
+	Size        int64    `json:"size"`
+	Percent     float64  `json:"percent"`
+	CreatedAt   string   `json:"createdAt"`
+	Tags        []string `json:"tags"`
+	Comment     string   `json:"comment"`
+	Empty       bool     `json:"empty"`
+}
+
+type InstructionSummary struct {
+	Instruction string  `json:"instruction"`
+	Layers      int     `json:"layers"`
+	Size        int64   `json:"size"`
+	Percent     float64 `json:"percent"`
+}
+
+// largestLayersCount is the number of layers returned in ImageHistory.Largest.
+const largestLayersCount = 5
+
+// History returns the layers of an image from the oldest to the newest
+// together with the instructions that contribute most to its size.
+func (s *DockerImagesService) History(id string) (ImageHistory, error) {
+	if s.cli == nil || s.ctx == nil {
+		return ImageHistory{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	inspect, err := s.cli.ImageInspect(s.ctx, id)
+	if err != nil {
+		return ImageHistory{}, fmt.Errorf("failed to get image data: %v", err)
+	}
+	items, err := s.cli.ImageHistory(s.ctx, id)
+	if err != nil {
+		return ImageHistory{}, fmt.Errorf("failed to get image history: %v", err)
+	}
+
+	history := ImageHistory{
+		ID:     strings.TrimPrefix(inspect.ID, "sha256:"),
+		Size:   inspect.Size,
+		Layers: make([]ImageLayer, 0, len(items)),
+	}
+
+	summaries := make(map[string]*InstructionSummary)
+	// The daemon returns the newest layer first
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		layer := ImageLayer{
+			ID:          strings.TrimPrefix(item.ID, "sha256:"),
+			Instruction: layerInstruction(item.CreatedBy),
+			CreatedBy:   cleanCreatedBy(item.CreatedBy),
+			Size:        item.Size,
+			Percent:     percentOf(item.Size, history.Size),
+			CreatedAt:   time.Unix(item.Created, 0).Format(time.RFC3339),
+			Tags:        item.Tags,
+			Comment:     item.Comment,
+			Empty:       item.Size == 0,
+		}
+		if layer.ID == "<missing>" {
+			layer.ID = ""
+		}
+		if layer.Tags == nil {
+			layer.Tags = []string{}
+		}
+		history.Layers = append(history.Layers, layer)
+
+		summary, ok := summaries[layer.Instruction]
+		if !ok {
+			summary = &InstructionSummary{Instruction: layer.Instruction}
+			summaries[layer.Instruction] = summary
+		}
+		summary.Layers++
+		summary.Size += layer.Size
+	}
+
+	history.Instructions = make([]InstructionSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		summary.Percent = percentOf(summary.Size, history.Size)
+		history.Instructions = append(history.Instructions, *summary)
+	}
+	sort.Slice(history.Instructions, func(i, j int) bool {
+		if history.Instructions[i].Size != history.Instructions[j].Size {
+			return history.Instructions[i].Size > history.Instructions[j].Size
+		}
+		return history.Instructions[i].Instruction < history.Instructions[j].Instruction
+	})
+
+	history.Largest = make([]ImageLayer, 0, largestLayersCount)
+	for _, layer := range history.Layers {
+		if !layer.Empty {
+			history.Largest = append(history.Largest, layer)
+		}
+	}
+	sort.SliceStable(history.Largest, func(i, j int) bool {
+		return history.Largest[i].Size > history.Largest[j].Size
+	})
+	if len(history.Largest) > largestLayersCount {
+		history.Largest = history.Largest[:largestLayersCount]
+	}
+
+	return history, nil
+}
+
+// cleanCreatedBy strips the shell wrapping the legacy builder puts around
+// instructions, turning "/bin/sh -c #(nop)  CMD [...]" into "CMD [...]" and
+// "/bin/sh -c apt-get ..." into "RUN apt-get ...".
+func cleanCreatedBy(createdBy string) string {
+	command := strings.TrimSpace(createdBy)
+	command = strings.TrimSpace(strings.TrimSuffix(command, "# buildkit"))
+	if idx := strings.Index(command, "#(nop)"); idx >= 0 {
+		return strings.TrimSpace(command[idx+len("#(nop)"):])
+	}
+
+	command = runArgsPrefixRegexp.ReplaceAllString(command, "")
+	if rest, ok := strings.CutPrefix(command, "/bin/sh -c "); ok {
+		return "RUN " + strings.TrimSpace(rest)
+	}
+	return command
+}
+
+func layerInstruction(createdBy string) string {
+	if match := nopInstructionRegexp.FindStringSubmatch(createdBy); match != nil {
+		return match[1]
+	}
+
+	command := cleanCreatedBy(createdBy)
+	word, _, _ := strings.Cut(command, " ")
+	if dockerfileInstructions[strings.ToUpper(word)] {
+		return strings.ToUpper(word)
+	}
+	if command == "" {
+		return "UNKNOWN"
+	}
+	return "RUN"
+}
+
+func percentOf(part int64, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100.0
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+)
+
+type ImagePruneOptions struct {
+	// All includes every image not used by a container, not only dangling
+	// ones.
+	All           bool `json:"all"`
+	OlderThanDays int  `json:"olderThanDays"`
+	// Labels holds "key", "key=value", "!key" or "!key=value" entries, the
+	// negated forms exclude matching images.
+	Labels []string `json:"labels"`
+}
+
+type ImagePruneCandidate struct {
+	ID          string   `json:"id"`
+	Tags        []string `json:"tags"`
+	Size        int64    `json:"size"`
+	Reclaimable int64    `json:"reclaimable"`
+	CreatedAt   string   `json:"createdAt"`
+	Dangling    bool     `json:"dangling"`
+}
+
+type ImagePrunePreview struct {
+	Images           []ImagePruneCandidate `json:"images"`
+	ReclaimableBytes int64                 `json:"reclaimableBytes"`
+}
+
+type ImagePruneReport struct {
+	Deleted        []string `json:"deleted"`
+	Untagged       []string `json:"untagged"`
+	ReclaimedBytes int64    `json:"reclaimedBytes"`
+	Errors         []string `json:"errors"`
+}
+
+// PreviewPrune lists the images Prune would remove with the same options.
+func (s *DockerImagesService) PreviewPrune(options ImagePruneOptions) (ImagePrunePreview, error) {
+	if s.cli == nil || s.ctx == nil {
+		return ImagePrunePreview{}, fmt.Errorf("Docker client not initialized")
+	}
+	if options.OlderThanDays < 0 {
+		return ImagePrunePreview{}, fmt.Errorf("age must not be negative")
+	}
+
+	images, err := s.cli.ImageList(s.ctx, image.ListOptions{SharedSize: true})
+	if err != nil {
+		return ImagePrunePreview{}, fmt.Errorf("failed to list images: %v", err)
+	}
+	used, err := s.usedImageIDs()
+	if err != nil {
+		return ImagePrunePreview{}, err
+	}
+
+	preview := ImagePrunePreview{Images: []ImagePruneCandidate{}}
+	cutoff := options.cutoff()
+	for _, img := range images {
+		dangling := isDanglingImage(img)
+		if used[img.ID] || (!options.All && !dangling) {
+			continue
+		}
+		if !cutoff.IsZero() && !time.Unix(img.Created, 0).Before(cutoff) {
+			continue
+		}
+		if !matchLabels(img.Labels, options.Labels) {
+			continue
+		}
+
+		// Layers shared with other images are only freed once all of them
+		// are gone, so they are not counted as reclaimable
+		reclaimable := img.Size
+		if img.SharedSize > 0 {
+			reclaimable -= img.SharedSize
+		}
+
+		tags := img.RepoTags
+		if dangling || tags == nil {
+			tags = []string{}
+		}
+		preview.Images = append(preview.Images, ImagePruneCandidate{
+			ID:          strings.TrimPrefix(img.ID, "sha256:"),
+			Tags:        tags,
+			Size:        img.Size,
+			Reclaimable: reclaimable,
+			CreatedAt:   time.Unix(img.Created, 0).Format(time.RFC3339),
+			Dangling:    dangling,
+		})
+		preview.ReclaimableBytes += reclaimable
+	}
+
+	sort.Slice(preview.Images, func(i, j int) bool {
+		return preview.Images[i].Reclaimable > preview.Images[j].Reclaimable
+	})
+	return preview, nil
+}
+
+// Prune removes the images matching options in a single ImagesPrune call.
+func (s *DockerImagesService) Prune(options ImagePruneOptions) (ImagePruneReport, error) {
+	if s.cli == nil || s.ctx == nil {
+		return ImagePruneReport{}, fmt.Errorf("Docker client not initialized")
+	}
+	if options.OlderThanDays < 0 {
+		return ImagePruneReport{}, fmt.Errorf("age must not be negative")
+	}
+
+	pruneFilters := filters.NewArgs()
+	pruneFilters.Add("dangling", fmt.Sprintf("%t", !options.All))
+	if cutoff := options.cutoff(); !cutoff.IsZero() {
+		pruneFilters.Add("until", cutoff.Format(time.RFC3339))
+	}
+	for _, label := range options.Labels {
+		if negated, ok := strings.CutPrefix(label, "!"); ok {
+			pruneFilters.Add("label!", negated)
+		} else {
+			pruneFilters.Add("label", label)
+		}
+	}
+
+	resp, err := s.cli.ImagesPrune(s.ctx, pruneFilters)
+	if err != nil {
+		return ImagePruneReport{}, fmt.Errorf("failed to prune images: %v", err)
+	}
+
+	report := newImagePruneReport()
+	report.ReclaimedBytes = int64(resp.SpaceReclaimed)
+	for _, item := range resp.ImagesDeleted {
+		report.add(item)
+	}
+	return report, nil
+}
+
+// PruneSelected removes exactly the given images, typically the ones shown by
+// PreviewPrune. Images still in use are reported as errors and left alone.
+func (s *DockerImagesService) PruneSelected(ids []string) (ImagePruneReport, error) {
+	if s.cli == nil || s.ctx == nil {
+		return ImagePruneReport{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	used, err := s.usedImageIDs()
+	if err != nil {
+		return ImagePruneReport{}, err
+	}
+
+	report := newImagePruneReport()
+	for _, id := range ids {
+		inspect, err := s.cli.ImageInspect(s.ctx, id)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		if used[inspect.ID] {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: image is used by a container", id))
+			continue
+		}
+
+		// Force is needed to remove images with several tags, images in use
+		// have been left out above
+		items, err := s.cli.ImageRemove(s.ctx, inspect.ID, image.RemoveOptions{Force: true, PruneChildren: true})
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		for _, item := range items {
+			report.add(item)
+			if item.Deleted == inspect.ID {
+				report.ReclaimedBytes += inspect.Size
+			}
+		}
+	}
+	return report, nil
+}
+
+func newImagePruneReport() ImagePruneReport {
+	return ImagePruneReport{
+		Deleted:  []string{},
+		Untagged: []string{},
+		Errors:   []string{},
+	}
+}
+
+func (r *ImagePruneReport) add(item image.DeleteResponse) {
+	if item.Deleted != "" {
+		r.Deleted = append(r.Deleted, strings.TrimPrefix(item.Deleted, "sha256:"))
+	}
+	if item.Untagged != "" {
+		r.Untagged = append(r.Untagged, item.Untagged)
+	}
+}
+
+func (o ImagePruneOptions) cutoff() time.Time {
+	if o.OlderThanDays <= 0 {
+		return time.Time{}
+	}
+	return time.Now().AddDate(0, 0, -o.OlderThanDays)
+}
+
+// usedImageIDs returns the IDs of images referenced by any container,
+// running or not.
+func (s *DockerImagesService) usedImageIDs() (map[string]bool, error) {
+	containers, err := s.cli.ContainerList(s.ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+	used := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		used[c.ImageID] = true
+	}
+	return used, nil
+}
+
+func isDanglingImage(img image.Summary) bool {
+	for _, tag := range img.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLabels applies label filters the way the daemon's prune filters do:
+// every positive filter has to match and no negated one may match.
+func matchLabels(labels map[string]string, labelFilters []string) bool {
+	for _, filter := range labelFilters {
+		negated := strings.HasPrefix(filter, "!")
+		key, value, hasValue := strings.Cut(strings.TrimPrefix(filter, "!"), "=")
+
+		actual, ok := labels[key]
+		matches := ok && (!hasValue || actual == value)
+		if matches == negated {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// progressInterval limits how often progress events are emitted while a
+// transfer is running.
+const progressInterval = 150 * time.Millisecond
+
+// LayerProgress tracks a single layer. For pushes Downloaded and
+// DownloadTotal count the uploaded bytes.
+type LayerProgress struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	Downloaded    int64  `json:"downloaded"`
+	DownloadTotal int64  `json:"downloadTotal"`
+	Extracted     int64  `json:"extracted"`
+	ExtractTotal  int64  `json:"extractTotal"`
+}
+
+type TransferProgress struct {
+	Ref           string          `json:"ref"`
+	Status        string          `json:"status"`
+	Layers        []LayerProgress `json:"layers"`
+	Downloaded    int64           `json:"downloaded"`
+	DownloadTotal int64           `json:"downloadTotal"`
+	Extracted     int64           `json:"extracted"`
+	ExtractTotal  int64           `json:"extractTotal"`
+	Done          bool            `json:"done"`
+	Error         string          `json:"error"`
+}
+
+type imageTransfer struct {
+	cancel context.CancelFunc
+}
+
+// Pull downloads an image in the background. Progress is emitted as
+// "docker:images:pull" events, the last one has Done set.
+func (s *DockerImagesService) Pull(ref string) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return fmt.Errorf("image reference is required")
+	}
+
+	auth, err := encodedRegistryAuthForRef(ref)
+	if err != nil {
+		return err
+	}
+
+	return s.startTransfer("pull:"+ref, func(ctx context.Context) (io.ReadCloser, error) {
+		return s.cli.ImagePull(ctx, ref, image.PullOptions{RegistryAuth: auth})
+	}, func(ctx context.Context, reader io.Reader) {
+		s.emitProgress(ctx, reader, "docker:images:pull", ref)
+	})
+}
+
+func (s *DockerImagesService) CancelPull(ref string) {
+	s.cancelTransfer("pull:" + strings.TrimSpace(ref))
+}
+
+// Push uploads an image to its registry in the background. Progress is
+// emitted as "docker:images:push" events, the last one has Done set.
+func (s *DockerImagesService) Push(ref string) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return fmt.Errorf("image reference is required")
+	}
+
+	auth, err := encodedRegistryAuthForRef(ref)
+	if err != nil {
+		return err
+	}
+
+	return s.startTransfer("push:"+ref, func(ctx context.Context) (io.ReadCloser, error) {
+		return s.cli.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+	}, func(ctx context.Context, reader io.Reader) {
+		s.emitProgress(ctx, reader, "docker:images:push", ref)
+	})
+}
+
+func (s *DockerImagesService) CancelPush(ref string) {
+	s.cancelTransfer("push:" + strings.TrimSpace(ref))
+}
+
+// startTransfer registers a cancellable background operation under key and
+// hands the response stream returned by start to consume. The transfer is
+// registered before start runs, so that it can already be cancelled while
+// the request is being sent.
+func (s *DockerImagesService) startTransfer(key string, start func(ctx context.Context) (io.ReadCloser, error), consume func(ctx context.Context, reader io.Reader)) error {
+	s.transfersMu.Lock()
+	if s.transfers == nil {
+		s.transfers = make(map[string]*imageTransfer)
+	}
+	if _, ok := s.transfers[key]; ok {
+		s.transfersMu.Unlock()
+		return fmt.Errorf("%s is already in progress", key)
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	transfer := &imageTransfer{cancel: cancel}
+	s.transfers[key] = transfer
+	s.transfersMu.Unlock()
+
+	release := func() {
+		s.transfersMu.Lock()
+		if s.transfers[key] == transfer {
+			delete(s.transfers, key)
+		}
+		s.transfersMu.Unlock()
+		cancel()
+	}
+
+	reader, err := start(ctx)
+	if err != nil {
+		release()
+		return err
+	}
+
+	go func() {
+		defer reader.Close()
+		defer release()
+
+		consume(ctx, reader)
+	}()
+
+	return nil
+}
+
+// emitProgress reports a pull or push stream as progress events, finishing
+// with an event that has Done set.
+func (s *DockerImagesService) emitProgress(ctx context.Context, reader io.Reader, event string, ref string) {
+	progress := &TransferProgress{Ref: ref}
+	err := trackProgress(reader, progress, func() {
+		runtime.EventsEmit(s.ctx, event, progress.snapshot())
+	})
+	if ctx.Err() != nil {
+		err = fmt.Errorf("cancelled")
+	}
+
+	progress.Done = true
+	if err != nil {
+		progress.Error = err.Error()
+	}
+	runtime.EventsEmit(s.ctx, event, progress.snapshot())
+}
+
+func (s *DockerImagesService) cancelTransfer(key string) {
+	s.transfersMu.Lock()
+	defer s.transfersMu.Unlock()
+
+	if transfer, ok := s.transfers[key]; ok {
+		transfer.cancel()
+		delete(s.transfers, key)
+	}
+}
+
+// trackProgress decodes the JSON message stream of a pull or push and folds
+// it into progress, calling emit at most once per progressInterval.
+func trackProgress(reader io.Reader, progress *TransferProgress, emit func()) error {
+	layers := make(map[string]*LayerProgress)
+	order := []string{}
+	lastEmit := time.Time{}
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("%s", msg.Error.Message)
+		}
+		if msg.ErrorMessage != "" {
+			return fmt.Errorf("%s", msg.ErrorMessage)
+		}
+
+		if msg.ID == "" || msg.Progress == nil && !isLayerStatus(msg.Status) {
+			progress.Status = msg.Status
+		} else {
+			layer, ok := layers[msg.ID]
+			if !ok {
+				layer = &LayerProgress{ID: msg.ID}
+				layers[msg.ID] = layer
+				order = append(order, msg.ID)
+			}
+			layer.Status = msg.Status
+			if msg.Progress != nil && msg.Progress.Total > 0 {
+				switch msg.Status {
+				case "Extracting":
+					layer.Extracted, layer.ExtractTotal = msg.Progress.Current, msg.Progress.Total
+				default:
+					layer.Downloaded, layer.DownloadTotal = msg.Progress.Current, msg.Progress.Total
+				}
+			}
+			switch msg.Status {
+			case "Download complete", "Pushed", "Layer already exists":
+				layer.Downloaded = layer.DownloadTotal
+			case "Pull complete":
+				layer.Downloaded = layer.DownloadTotal
+				layer.Extracted = layer.ExtractTotal
+			}
+			progress.collect(order, layers)
+		}
+
+		if time.Since(lastEmit) >= progressInterval {
+			lastEmit = time.Now()
+			emit()
+		}
+	}
+}
+
+func isLayerStatus(status string) bool {
+	switch status {
+	case "Pulling fs layer", "Waiting", "Verifying Checksum", "Download complete",
+		"Pull complete", "Already exists", "Preparing", "Pushed", "Layer already exists":
+		return true
+	}
+	return false
+}
+
+// collect recomputes the layer list and totals, keeping layers in the order
+// they were first reported.
+func (p *TransferProgress) collect(order []string, layers map[string]*LayerProgress) {
+	p.Layers = make([]LayerProgress, 0, len(order))
+	p.Downloaded, p.DownloadTotal, p.Extracted, p.ExtractTotal = 0, 0, 0, 0
+	for _, id := range order {
+		layer := layers[id]
+		p.Layers = append(p.Layers, *layer)
+		p.Downloaded += layer.Downloaded
+		p.DownloadTotal += layer.DownloadTotal
+		p.Extracted += layer.Extracted
+		p.ExtractTotal += layer.ExtractTotal
+	}
+}
+
+func (p *TransferProgress) snapshot() TransferProgress {
+	result := *p
+	result.Layers = append([]LayerProgress{}, p.Layers...)
+	return result
+}
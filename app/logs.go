@@ -1,25 +1,54 @@
 package app
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type DockerLogsService struct {
 	DockerBaseService
+	streams map[string]*logStream
+	mu      sync.Mutex
+}
+
+type logStream struct {
 	cancel context.CancelFunc
-	mu     sync.Mutex
+}
+
+// LogEntry is emitted as "docker:logs" event. StreamID is the ID passed to
+// StartWatching, so that several log views can share the event.
+type LogEntry struct {
+	StreamID      string `json:"streamId"`
+	ContainerID   string `json:"containerId"`
+	ContainerName string `json:"containerName"`
+	// Stream is "stdout", "stderr" or "error" when reading the logs failed.
+	// Containers with a TTY only have stdout.
+	Stream    string `json:"stream"`
+	Timestamp string `json:"timestamp"`
+	Text      string `json:"text"`
+	// Level is the detected log level, empty when unknown
+	Level string `json:"level"`
+	// Match is unset for lines only sent as context of a filter match
+	Match bool `json:"match"`
 }
 
 func NewDockerLogsService() *DockerLogsService {
-	return &DockerLogsService{}
+	return &DockerLogsService{
+		streams: make(map[string]*logStream),
+	}
 }
 
 func StartupDockerLogsService(s *DockerLogsService, ctx context.Context, cli *client.Client) {
@@ -27,65 +56,403 @@ func StartupDockerLogsService(s *DockerLogsService, ctx context.Context, cli *cl
 	s.cli = cli
 }
 
-func (s *DockerLogsService) StartWatching(id string) error {
+// LogQuery selects which logs are read. Since and Until accept a duration
+// relative to now, like "15m" or "2h30m", or an RFC3339 time.
+type LogQuery struct {
+	// Tail is the number of lines to show from the end of the logs, or "all"
+	Tail       string    `json:"tail"`
+	Since      string    `json:"since"`
+	Until      string    `json:"until"`
+	Follow     bool      `json:"follow"`
+	Timestamps bool      `json:"timestamps"`
+	Filter     LogFilter `json:"filter"`
+}
+
+type logSource struct {
+	out   io.ReadCloser
+	entry LogEntry
+	tty   bool
+}
+
+// StartWatching reads the logs of the given containers, interleaved, as
+// "docker:logs" events tagged with streamID. With query.Follow the stream
+// keeps running until StopWatching is called. A stream with the same ID is
+// replaced.
+func (s *DockerLogsService) StartWatching(streamID string, ids []string, query LogQuery) error {
 	if s.cli == nil || s.ctx == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if streamID == "" {
+		return fmt.Errorf("stream ID is required")
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no containers selected")
+	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.start(streamID, ids, query)
+}
+
+// StartProjectWatching reads the logs of every container of a compose
+// project, see StartWatching.
+func (s *DockerLogsService) StartProjectWatching(streamID string, project string, query LogQuery) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	if streamID == "" {
+		return fmt.Errorf("stream ID is required")
+	}
+
+	ids, err := s.projectContainerIDs(project)
+	if err != nil {
+		return err
+	}
+	return s.start(streamID, ids, query)
+}
+
+// Fetch returns the logs of the given containers selected by query, ordered
+// by time. Follow is ignored. Tail is applied before the filter.
+func (s *DockerLogsService) Fetch(ids []string, query LogQuery) ([]LogEntry, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no containers selected")
+	}
+
+	query.Follow = false
+	matcher, err := query.Filter.compile()
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.openLogs(s.ctx, "", ids, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]LogEntry, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer source.out.Close()
+			s.follow(s.ctx, source, matcher.wrap(func(entry LogEntry) {
+				results[i] = append(results[i], entry)
+			}))
+		}()
+	}
+	wg.Wait()
 
-	// Don't start multiple listeners
-	if s.cancel != nil {
-		return nil
+	entries := []LogEntry{}
+	for _, result := range results {
+		entries = append(entries, result...)
 	}
+	sortLogEntries(entries)
+	if !query.Timestamps {
+		for i := range entries {
+			entries[i].Timestamp = ""
+		}
+	}
+	return entries, nil
+}
 
+func (s *DockerLogsService) start(streamID string, ids []string, query LogQuery) error {
+	matcher, err := query.Filter.compile()
+	if err != nil {
+		return err
+	}
+
+	// The stream is registered first so that StopWatching can cancel it
+	// while the logs are being opened, which happens outside the lock as
+	// waiting for the daemon must not block the other streams
 	ctx, cancel := context.WithCancel(s.ctx)
-	s.cancel = cancel
+	stream := &logStream{cancel: cancel}
+	s.mu.Lock()
+	if previous, ok := s.streams[streamID]; ok {
+		previous.cancel()
+	}
+	s.streams[streamID] = stream
+	s.mu.Unlock()
+
+	sources, err := s.openLogs(ctx, streamID, ids, query)
+	if err != nil {
+		s.release(streamID, stream)
+		return err
+	}
+
+	emit := func(entry LogEntry) {
+		if !query.Timestamps {
+			entry.Timestamp = ""
+		}
+		runtime.EventsEmit(s.ctx, "docker:logs", entry)
+	}
+
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer source.out.Close()
+			s.follow(ctx, source, matcher.wrap(emit))
+		}()
+	}
+	go func() {
+		wg.Wait()
+		s.release(streamID, stream)
+	}()
+
+	return nil
+}
+
+// openLogs opens the logs of every container. Timestamps are always
+// requested, they are needed to order the entries of several containers.
+func (s *DockerLogsService) openLogs(ctx context.Context, streamID string, ids []string, query LogQuery) ([]logSource, error) {
+	options, err := query.options()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]logSource, 0, len(ids))
+	closeSources := func() {
+		for _, source := range sources {
+			source.out.Close()
+		}
+	}
+	for _, id := range ids {
+		inspect, err := s.cli.ContainerInspect(ctx, id)
+		if err != nil {
+			closeSources()
+			return nil, fmt.Errorf("failed to get container data: %v", err)
+		}
+
+		out, err := s.cli.ContainerLogs(ctx, id, options)
+		if err != nil {
+			closeSources()
+			return nil, fmt.Errorf("failed to get container logs: %v", err)
+		}
+		sources = append(sources, logSource{
+			out: out,
+			entry: LogEntry{
+				StreamID:      streamID,
+				ContainerID:   id,
+				ContainerName: strings.TrimPrefix(inspect.Name, "/"),
+			},
+			tty: inspect.Config != nil && inspect.Config.Tty,
+		})
+	}
+	return sources, nil
+}
+
+func (s *DockerLogsService) projectContainerIDs(project string) ([]string, error) {
+	projectFilter := filters.NewArgs(filters.Arg("label", composeProjectLabel+"="+project))
+	containers, err := s.cli.ContainerList(s.ctx, container.ListOptions{All: true, Filters: projectFilter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("project %s has no containers", project)
+	}
+
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
+
+func (q LogQuery) options() (container.LogsOptions, error) {
+	tail := strings.TrimSpace(q.Tail)
+	if tail == "" {
+		tail = "all"
+	}
+	if tail != "all" {
+		if n, err := strconv.Atoi(tail); err != nil || n < 0 {
+			return container.LogsOptions{}, fmt.Errorf("invalid tail %q, expected a number of lines or \"all\"", q.Tail)
+		}
+	}
+
+	since, err := parseLogTime(q.Since)
+	if err != nil {
+		return container.LogsOptions{}, fmt.Errorf("invalid since: %v", err)
+	}
+	until, err := parseLogTime(q.Until)
+	if err != nil {
+		return container.LogsOptions{}, fmt.Errorf("invalid until: %v", err)
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return container.LogsOptions{}, fmt.Errorf("since must be before until")
+	}
 
 	options := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Follow:     true,
-		Timestamps: false,
-		Tail:       "10",
+		Follow:     q.Follow,
+		Timestamps: true,
+		Tail:       tail,
+	}
+	if !since.IsZero() {
+		options.Since = since.Format(time.RFC3339Nano)
+	}
+	if !until.IsZero() {
+		options.Until = until.Format(time.RFC3339Nano)
 	}
+	return options, nil
+}
 
-	out, err := s.cli.ContainerLogs(ctx, id, options)
+// parseLogTime accepts a duration before now or an RFC3339 time. The zero
+// time is returned for an empty value.
+func parseLogTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", value)
+		}
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
 	if err != nil {
-		return err
+		return time.Time{}, fmt.Errorf("%q is neither a duration like 15m nor an RFC3339 time", value)
 	}
+	return t, nil
+}
 
-	go func() {
-		defer out.Close()
-		reader := bufio.NewReader(out)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				line, err := reader.ReadBytes('\n')
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					runtime.EventsEmit(s.ctx, "docker:logs", "ERROR: "+err.Error())
-					break
-				}
-				runtime.EventsEmit(s.ctx, "docker:logs", string(line))
-			}
+// sortLogEntries orders entries of several containers by time, keeping the
+// order of entries with equal or missing timestamps.
+func sortLogEntries(entries []LogEntry) {
+	times := make(map[string]time.Time, len(entries))
+	for _, entry := range entries {
+		if _, ok := times[entry.Timestamp]; !ok {
+			t, _ := time.Parse(time.RFC3339Nano, entry.Timestamp)
+			times[entry.Timestamp] = t
 		}
-	}()
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return times[entries[i].Timestamp].Before(times[entries[j].Timestamp])
+	})
+}
 
-	return nil
+// follow reads the logs until they end or ctx is cancelled, passing every
+// line to emit. Without a TTY the daemon multiplexes stdout and stderr into
+// frames with an 8 byte header, which are split up again here.
+func (s *DockerLogsService) follow(ctx context.Context, source logSource, emit func(LogEntry)) {
+	stdout := newLogLineWriter(ctx, source.entry, "stdout", emit)
+	stderr := newLogLineWriter(ctx, source.entry, "stderr", emit)
+
+	var err error
+	if source.tty {
+		_, err = io.Copy(stdout, source.out)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, source.out)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	stdout.flush()
+	stderr.flush()
+
+	if err != nil {
+		entry := source.entry
+		entry.Stream = "error"
+		entry.Text = err.Error()
+		emit(entry)
+	}
+}
+
+func newLogLineWriter(ctx context.Context, entry LogEntry, stream string, emit func(LogEntry)) *logLineWriter {
+	entry.Stream = stream
+	return &logLineWriter{ctx: ctx, emit: func(line string) {
+		entry.Timestamp, entry.Text = splitLogTimestamp(line)
+		entry.Level = detectLogLevel(entry.Text)
+		emit(entry)
+	}}
+}
+
+// logLineWriter calls emit for every complete line written to it.
+type logLineWriter struct {
+	ctx  context.Context
+	buf  []byte
+	emit func(line string)
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.emit(strings.TrimSuffix(string(w.buf[:idx]), "\r"))
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// flush emits a last line that did not end with a newline.
+func (w *logLineWriter) flush() {
+	if len(w.buf) > 0 {
+		w.emit(strings.TrimSuffix(string(w.buf), "\r"))
+		w.buf = nil
+	}
+}
+
+// splitLogTimestamp separates the RFC3339 timestamp the daemon puts in front
+// of every line from the text.
+func splitLogTimestamp(line string) (string, string) {
+	timestamp, text, found := strings.Cut(line, " ")
+	if !found {
+		timestamp = line
+	}
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return "", line
+	}
+	return timestamp, text
+}
+
+func (s *DockerLogsService) StopWatching(streamID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stream, ok := s.streams[streamID]; ok {
+		stream.cancel()
+		delete(s.streams, streamID)
+	}
+}
+
+func (s *DockerLogsService) StopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, stream := range s.streams {
+		stream.cancel()
+		delete(s.streams, id)
+	}
+}
+
+func (s *DockerLogsService) Watched() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.streams))
+	for id := range s.streams {
+		ids = append(ids, id)
+	}
+	return ids
 }
 
-func (s *DockerLogsService) StopWatching() {
+// release forgets a stream whose containers all stopped logging, unless it
+// was already replaced by a newer one with the same ID.
+func (s *DockerLogsService) release(streamID string, stream *logStream) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.cancel != nil {
-		s.cancel()
-		s.cancel = nil
+	stream.cancel()
+	if s.streams[streamID] == stream {
+		delete(s.streams, streamID)
 	}
 }
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// logLevels orders the normalized levels, higher is more severe.
+var logLevels = map[string]int{
+	"trace": 0,
+	"debug": 1,
+	"info":  2,
+	"warn":  3,
+	"error": 4,
+	"fatal": 5,
+}
+
+var (
+	logLevelRegexp    = regexp.MustCompile(`(?i)\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|ERR|CRIT|CRITICAL|FATAL|PANIC)\b`)
+	logfmtLevelRegexp = regexp.MustCompile(`(?i)\b(?:level|lvl|severity)=["']?([a-z]+)`)
+)
+
+// logLevelFields are the JSON fields structured loggers use for the level.
+var logLevelFields = []string{"level", "lvl", "severity", "log.level", "loglevel"}
+
+// LogFilter selects log lines on the server so that only the matching ones
+// are sent to the frontend. An empty filter matches every line.
+type LogFilter struct {
+	// Include holds regular expressions of which at least one has to match
+	Include []string `json:"include"`
+	// Exclude holds regular expressions of which none may match
+	Exclude []string `json:"exclude"`
+	// Search is a case insensitive substring
+	Search string `json:"search"`
+	// MinLevel is one of "trace", "debug", "info", "warn", "error" or
+	// "fatal". Lines without a detectable level are left out when it is set.
+	MinLevel string `json:"minLevel"`
+	// Before and After are the number of lines around every match that are
+	// sent as context, with Match unset
+	Before int `json:"before"`
+	After  int `json:"after"`
+}
+
+type logMatcher struct {
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+	search   string
+	minLevel int
+	before   int
+	after    int
+	active   bool
+}
+
+func (f LogFilter) compile() (*logMatcher, error) {
+	if f.Before < 0 || f.After < 0 {
+		return nil, fmt.Errorf("context lines must not be negative")
+	}
+
+	m := &logMatcher{
+		search:   strings.ToLower(f.Search),
+		minLevel: -1,
+		before:   f.Before,
+		after:    f.After,
+	}
+	for _, pattern := range f.Include {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern %q: %v", pattern, err)
+		}
+		m.include = append(m.include, re)
+	}
+	for _, pattern := range f.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %v", pattern, err)
+		}
+		m.exclude = append(m.exclude, re)
+	}
+	if f.MinLevel != "" {
+		level := normalizeLogLevel(f.MinLevel)
+		if level == "" {
+			return nil, fmt.Errorf("unknown log level %q", f.MinLevel)
+		}
+		m.minLevel = logLevels[level]
+	}
+
+	m.active = len(m.include) > 0 || len(m.exclude) > 0 || m.search != "" || m.minLevel >= 0
+	return m, nil
+}
+
+func (m *logMatcher) matches(entry LogEntry) bool {
+	if entry.Stream == "error" {
+		return true
+	}
+	if m.minLevel >= 0 {
+		level, ok := logLevels[entry.Level]
+		if !ok || level < m.minLevel {
+			return false
+		}
+	}
+	if m.search != "" && !strings.Contains(strings.ToLower(entry.Text), m.search) {
+		return false
+	}
+	if len(m.include) > 0 {
+		included := false
+		for _, re := range m.include {
+			if re.MatchString(entry.Text) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, re := range m.exclude {
+		if re.MatchString(entry.Text) {
+			return false
+		}
+	}
+	return true
+}
+
+// wrap returns an emit function that only passes on matching entries and
+// their context. Every log source needs its own, as the context is tracked
+// per container.
+func (m *logMatcher) wrap(emit func(LogEntry)) func(LogEntry) {
+	if !m.active {
+		return func(entry LogEntry) {
+			entry.Match = true
+			emit(entry)
+		}
+	}
+
+	var before []LogEntry
+	after := 0
+	return func(entry LogEntry) {
+		if m.matches(entry) {
+			for _, line := range before {
+				emit(line)
+			}
+			before = before[:0]
+			entry.Match = true
+			emit(entry)
+			after = m.after
+			return
+		}
+		if after > 0 {
+			after--
+			emit(entry)
+			return
+		}
+		if m.before > 0 {
+			before = append(before, entry)
+			if len(before) > m.before {
+				before = before[1:]
+			}
+		}
+	}
+}
+
+// detectLogLevel guesses the level of a log line from a JSON "level" field,
+// a logfmt level=... pair or a level word such as "ERROR" or "Warning:".
+func detectLogLevel(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") {
+		var fields map[string]any
+		if json.Unmarshal([]byte(trimmed), &fields) == nil {
+			for _, key := range logLevelFields {
+				var level string
+				switch value := fields[key].(type) {
+				case string:
+					level = normalizeLogLevel(value)
+				case float64:
+					level = numericLogLevel(value)
+				}
+				if level != "" {
+					return level
+				}
+			}
+		}
+	}
+	if match := logfmtLevelRegexp.FindStringSubmatch(text); match != nil {
+		if level := normalizeLogLevel(match[1]); level != "" {
+			return level
+		}
+	}
+	if match := logLevelRegexp.FindString(text); match != "" {
+		return normalizeLogLevel(match)
+	}
+	return ""
+}
+
+// numericLogLevel maps the numeric levels of pino and bunyan, 10 for trace
+// up to 60 for fatal.
+func numericLogLevel(level float64) string {
+	switch {
+	case level >= 60:
+		return "fatal"
+	case level >= 50:
+		return "error"
+	case level >= 40:
+		return "warn"
+	case level >= 30:
+		return "info"
+	case level >= 20:
+		return "debug"
+	case level >= 10:
+		return "trace"
+	}
+	return ""
+}
+
+func normalizeLogLevel(level string) string {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "trace":
+		return "trace"
+	case "debug", "dbg":
+		return "debug"
+	case "info", "information", "notice":
+		return "info"
+	case "warn", "warning":
+		return "warn"
+	case "error", "err":
+		return "error"
+	case "fatal", "panic", "crit", "critical", "emerg", "alert":
+		return "fatal"
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLogLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "json string", text: `{"level":"warn","msg":"disk almost full"}`, want: "warn"},
+		{name: "json severity", text: `{"severity":"ERROR","message":"failed"}`, want: "error"},
+		{name: "json numeric trace", text: `{"level":10,"msg":"x"}`, want: "trace"},
+		{name: "json numeric debug", text: `{"level":20,"msg":"x"}`, want: "debug"},
+		{name: "json numeric info", text: `{"level":30,"msg":"x"}`, want: "info"},
+		{name: "json numeric warn", text: `{"level":40,"msg":"x"}`, want: "warn"},
+		{name: "json numeric error", text: `{"level":50,"msg":"x"}`, want: "error"},
+		{name: "json numeric fatal", text: `{"level":60,"msg":"x"}`, want: "fatal"},
+		{name: "json unknown numeric", text: `{"level":3,"msg":"x"}`, want: ""},
+		{name: "logfmt", text: `time=2024-01-01T00:00:00Z level=debug msg="cache miss"`, want: "debug"},
+		{name: "logfmt quoted", text: `lvl="warning" msg=slow`, want: "warn"},
+		{name: "plain upper case", text: "2024/01/01 12:00:00 [ERROR] connection refused", want: "error"},
+		{name: "plain capitalized", text: "Error: connection refused", want: "error"},
+		{name: "plain warning", text: "Warning: deprecated option", want: "warn"},
+		{name: "plain notice", text: "NOTICE: ready to accept connections", want: "info"},
+		{name: "plain panic", text: "panic: runtime error", want: "fatal"},
+		{name: "no level", text: "listening on :8080", want: ""},
+		{name: "word inside another word", text: "informational output", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLogLevel(tt.text); got != tt.want {
+				t.Errorf("detectLogLevel(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogFilter(t *testing.T) {
+	lines := []string{
+		"INFO starting",
+		"DEBUG loading config",
+		"WARN config file missing",
+		"INFO listening on :8080",
+		"ERROR request failed",
+		"DEBUG retrying",
+		"INFO request done",
+	}
+
+	tests := []struct {
+		name    string
+		filter  LogFilter
+		want    []string
+		context []string
+	}{
+		{
+			name:   "empty filter",
+			filter: LogFilter{},
+			want:   lines,
+		},
+		{
+			name:   "include",
+			filter: LogFilter{Include: []string{"^INFO", "failed"}},
+			want:   []string{"INFO starting", "INFO listening on :8080", "ERROR request failed", "INFO request done"},
+		},
+		{
+			name:   "exclude",
+			filter: LogFilter{Exclude: []string{"^DEBUG", "request"}},
+			want:   []string{"INFO starting", "WARN config file missing", "INFO listening on :8080"},
+		},
+		{
+			name:   "include and exclude",
+			filter: LogFilter{Include: []string{"request"}, Exclude: []string{"done"}},
+			want:   []string{"ERROR request failed"},
+		},
+		{
+			name:   "search is case insensitive",
+			filter: LogFilter{Search: "CONFIG"},
+			want:   []string{"DEBUG loading config", "WARN config file missing"},
+		},
+		{
+			name:   "min level",
+			filter: LogFilter{MinLevel: "warn"},
+			want:   []string{"WARN config file missing", "ERROR request failed"},
+		},
+		{
+			name:   "min level alias",
+			filter: LogFilter{MinLevel: "Warning"},
+			want:   []string{"WARN config file missing", "ERROR request failed"},
+		},
+		{
+			name:    "before context",
+			filter:  LogFilter{Search: "failed", Before: 2},
+			want:    []string{"WARN config file missing", "INFO listening on :8080", "ERROR request failed"},
+			context: []string{"WARN config file missing", "INFO listening on :8080"},
+		},
+		{
+			name:    "after context",
+			filter:  LogFilter{Search: "failed", After: 1},
+			want:    []string{"ERROR request failed", "DEBUG retrying"},
+			context: []string{"DEBUG retrying"},
+		},
+		{
+			name:    "overlapping context is sent once",
+			filter:  LogFilter{MinLevel: "warn", Before: 1, After: 1},
+			want:    []string{"DEBUG loading config", "WARN config file missing", "INFO listening on :8080", "ERROR request failed", "DEBUG retrying"},
+			context: []string{"DEBUG loading config", "INFO listening on :8080", "DEBUG retrying"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := tt.filter.compile()
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+
+			got := []string{}
+			context := []string{}
+			emit := matcher.wrap(func(entry LogEntry) {
+				got = append(got, entry.Text)
+				if !entry.Match {
+					context = append(context, entry.Text)
+				}
+			})
+			for _, line := range lines {
+				emit(LogEntry{Stream: "stdout", Text: line, Level: detectLogLevel(line)})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if tt.context == nil {
+				tt.context = []string{}
+			}
+			if !reflect.DeepEqual(context, tt.context) {
+				t.Errorf("got context %q, want %q", context, tt.context)
+			}
+		})
+	}
+}
+
+func TestLogFilterErrorStream(t *testing.T) {
+	matcher, err := LogFilter{Search: "nothing matches this"}.compile()
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	var got []LogEntry
+	emit := matcher.wrap(func(entry LogEntry) {
+		got = append(got, entry)
+	})
+	emit(LogEntry{Stream: "error", Text: "container stopped"})
+
+	if len(got) != 1 || !got[0].Match {
+		t.Errorf("error entries have to pass every filter, got %+v", got)
+	}
+}
+
+func TestLogFilterCompileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter LogFilter
+	}{
+		{name: "invalid include", filter: LogFilter{Include: []string{"("}}},
+		{name: "invalid exclude", filter: LogFilter{Exclude: []string{"[a-"}}},
+		{name: "unknown level", filter: LogFilter{MinLevel: "verbose"}},
+		{name: "negative before", filter: LogFilter{Before: -1}},
+		{name: "negative after", filter: LogFilter{After: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.filter.compile(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
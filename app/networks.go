@@ -3,9 +3,13 @@ package app
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
@@ -20,8 +24,32 @@ type DockerNetworksService struct {
 }
 
 type NetworkInfo struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Driver     string             `json:"driver"`
+	Scope      string             `json:"scope"`
+	Internal   bool               `json:"internal"`
+	Attachable bool               `json:"attachable"`
+	EnableIPv6 bool               `json:"enableIPv6"`
+	Subnets    []NetworkSubnet    `json:"subnets"`
+	CreatedAt  string             `json:"createdAt"`
+	Labels     map[string]string  `json:"labels"`
+	Containers []NetworkContainer `json:"containers"`
+}
+
+type NetworkSubnet struct {
+	Subnet  string `json:"subnet"`
+	Gateway string `json:"gateway"`
+	IPRange string `json:"ipRange"`
+}
+
+type NetworkContainer struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	IPv4Address string   `json:"ipv4Address"`
+	IPv6Address string   `json:"ipv6Address"`
+	MacAddress  string   `json:"macAddress"`
+	Aliases     []string `json:"aliases"`
 }
 
 func NewDockerNetworksService() *DockerNetworksService {
@@ -37,12 +65,22 @@ func (s *DockerNetworksService) List() ([]NetworkInfo, error) {
 	if s.cli == nil || s.ctx == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
+	return s.list()
+}
+
+func (s *DockerNetworksService) list() ([]NetworkInfo, error) {
 	list, err := s.cli.NetworkList(s.ctx, network.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list networks: %v", err)
 	}
+	// The network list does not include the attached containers, the
+	// container list has them for all networks at once
+	containers, err := s.cli.ContainerList(s.ctx, container.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
 
-	result := s.formatList(list)
+	result := s.formatList(list, containers)
 	return result, nil
 }
 
@@ -102,24 +140,73 @@ func (s *DockerNetworksService) StopWatching() {
 	}
 }
 
-func (s *DockerNetworksService) formatList(networks []network.Summary) []NetworkInfo {
+func (s *DockerNetworksService) formatList(networks []network.Summary, containers []container.Summary) []NetworkInfo {
+	members := make(map[string][]NetworkContainer)
+	for _, c := range containers {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for _, endpoint := range c.NetworkSettings.Networks {
+			if endpoint == nil || endpoint.NetworkID == "" {
+				continue
+			}
+			aliases := endpoint.Aliases
+			if aliases == nil {
+				aliases = []string{}
+			}
+			members[endpoint.NetworkID] = append(members[endpoint.NetworkID], NetworkContainer{
+				ID:          c.ID,
+				Name:        containerName(c),
+				IPv4Address: endpoint.IPAddress,
+				IPv6Address: endpoint.GlobalIPv6Address,
+				MacAddress:  endpoint.MacAddress,
+				Aliases:     aliases,
+			})
+		}
+	}
+
 	list := make([]NetworkInfo, 0, len(networks))
 	for _, network := range networks {
-		list = append(list, NetworkInfo{
-			ID:   network.ID,
-			Name: network.Name,
+		info := NetworkInfo{
+			ID:         network.ID,
+			Name:       network.Name,
+			Driver:     network.Driver,
+			Scope:      network.Scope,
+			Internal:   network.Internal,
+			Attachable: network.Attachable,
+			EnableIPv6: network.EnableIPv6,
+			Subnets:    make([]NetworkSubnet, 0, len(network.IPAM.Config)),
+			CreatedAt:  network.Created.Format(time.RFC3339),
+			Labels:     network.Labels,
+			Containers: members[network.ID],
+		}
+		for _, config := range network.IPAM.Config {
+			info.Subnets = append(info.Subnets, NetworkSubnet{
+				Subnet:  config.Subnet,
+				Gateway: config.Gateway,
+				IPRange: config.IPRange,
+			})
+		}
+		if info.Labels == nil {
+			info.Labels = map[string]string{}
+		}
+		if info.Containers == nil {
+			info.Containers = []NetworkContainer{}
+		}
+		sort.Slice(info.Containers, func(i, j int) bool {
+			return info.Containers[i].Name < info.Containers[j].Name
 		})
+		list = append(list, info)
 	}
 	return list
 }
 
 func (s *DockerNetworksService) sendListUpdate() {
-	list, err := s.cli.NetworkList(s.ctx, network.ListOptions{})
+	result, err := s.list()
 	if err != nil {
 		return
 	}
 
-	result := s.formatList(list)
 	runtime.EventsEmit(s.ctx, "docker:networks", result)
 }
 
@@ -131,6 +218,74 @@ func (s *DockerNetworksService) Remove(id string) error {
 	return err
 }
 
+// Connect attaches a container to a network. The aliases make the container
+// reachable under additional names on that network, ipv4 assigns it a static
+// address and requires a network with a user defined subnet.
+func (s *DockerNetworksService) Connect(networkID string, containerID string, aliases []string, ipv4 string) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+
+	endpoint := &network.EndpointSettings{Aliases: aliases}
+	if ipv4 != "" {
+		addr, err := netip.ParseAddr(ipv4)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("invalid IPv4 address %q", ipv4)
+		}
+		if err := s.checkStaticAddress(networkID, addr); err != nil {
+			return err
+		}
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: addr.String()}
+	}
+
+	if err := s.cli.NetworkConnect(s.ctx, networkID, containerID, endpoint); err != nil {
+		return fmt.Errorf("failed to connect container: %v", err)
+	}
+	return nil
+}
+
+func (s *DockerNetworksService) Disconnect(networkID string, containerID string, force bool) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	if err := s.cli.NetworkDisconnect(s.ctx, networkID, containerID, force); err != nil {
+		return fmt.Errorf("failed to disconnect container: %v", err)
+	}
+	return nil
+}
+
+// checkStaticAddress makes sure addr belongs to one of the user defined
+// subnets of the network, giving a clearer error than the daemon.
+func (s *DockerNetworksService) checkStaticAddress(networkID string, addr netip.Addr) error {
+	inspect, err := s.cli.NetworkInspect(s.ctx, networkID, network.InspectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get network data: %v", err)
+	}
+
+	var subnets []string
+	for _, config := range inspect.IPAM.Config {
+		prefix, err := netip.ParsePrefix(config.Subnet)
+		if err != nil || !prefix.Addr().Is4() {
+			continue
+		}
+		if prefix.Contains(addr) {
+			return nil
+		}
+		subnets = append(subnets, config.Subnet)
+	}
+	if len(subnets) == 0 {
+		return fmt.Errorf("network %s has no IPv4 subnet configured, static addresses are not supported", inspect.Name)
+	}
+	return fmt.Errorf("address %s is not in the subnets of network %s: %s", addr, inspect.Name, strings.Join(subnets, ", "))
+}
+
+// isNetworkMembershipEvent reports whether event is a container being
+// connected to or disconnected from a network.
+func isNetworkMembershipEvent(event events.Message) bool {
+	return event.Type == events.NetworkEventType &&
+		(event.Action == events.ActionConnect || event.Action == events.ActionDisconnect)
+}
+
 func (s *DockerNetworksService) Inspect(id string) (string, error) {
 	if s.cli == nil || s.ctx == nil {
 		return "{}", fmt.Errorf("Docker client not initialized")
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+var networkNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+type NetworkSpec struct {
+	Name string `json:"name"`
+	// Driver is one of "bridge", "macvlan", "ipvlan" or "overlay", bridge is
+	// used when empty
+	Driver     string            `json:"driver"`
+	Subnets    []SubnetSpec      `json:"subnets"`
+	Internal   bool              `json:"internal"`
+	Attachable bool              `json:"attachable"`
+	EnableIPv6 bool              `json:"enableIPv6"`
+	Options    map[string]string `json:"options"`
+	Labels     map[string]string `json:"labels"`
+}
+
+type SubnetSpec struct {
+	Subnet  string `json:"subnet"`
+	Gateway string `json:"gateway"`
+	IPRange string `json:"ipRange"`
+}
+
+type NetworkCreateResult struct {
+	ID      string `json:"id"`
+	Warning string `json:"warning"`
+}
+
+func (s *DockerNetworksService) Create(spec NetworkSpec) (NetworkCreateResult, error) {
+	if s.cli == nil || s.ctx == nil {
+		return NetworkCreateResult{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	if err := spec.Validate(); err != nil {
+		return NetworkCreateResult{}, err
+	}
+
+	networks, err := s.cli.NetworkList(s.ctx, network.ListOptions{})
+	if err != nil {
+		return NetworkCreateResult{}, fmt.Errorf("failed to list networks: %v", err)
+	}
+	for _, existing := range networks {
+		if existing.Name == spec.Name {
+			return NetworkCreateResult{}, fmt.Errorf("network %s already exists", spec.Name)
+		}
+	}
+	if err := spec.checkOverlaps(networks); err != nil {
+		return NetworkCreateResult{}, err
+	}
+
+	enableIPv6 := spec.EnableIPv6
+	options := network.CreateOptions{
+		Driver:     spec.driver(),
+		EnableIPv6: &enableIPv6,
+		Internal:   spec.Internal,
+		Attachable: spec.Attachable,
+		Options:    spec.Options,
+		Labels:     spec.Labels,
+	}
+	if ipam := spec.ipam(); len(ipam.Config) > 0 {
+		options.IPAM = ipam
+	}
+
+	resp, err := s.cli.NetworkCreate(s.ctx, spec.Name, options)
+	if err != nil {
+		return NetworkCreateResult{}, fmt.Errorf("failed to create network: %v", err)
+	}
+	return NetworkCreateResult{ID: resp.ID, Warning: resp.Warning}, nil
+}
+
+func (spec NetworkSpec) Validate() error {
+	if !networkNameRegexp.MatchString(spec.Name) {
+		return fmt.Errorf("invalid network name %q", spec.Name)
+	}
+	switch spec.driver() {
+	case "bridge", "macvlan", "ipvlan", "overlay":
+	default:
+		return fmt.Errorf("unsupported network driver %q", spec.Driver)
+	}
+	if spec.Attachable && spec.driver() != "overlay" {
+		return fmt.Errorf("only overlay networks can be attachable")
+	}
+
+	for _, subnet := range spec.Subnets {
+		if subnet.Subnet == "" {
+			return fmt.Errorf("subnet is required when a gateway or IP range is set")
+		}
+	}
+	if err := network.ValidateIPAM(spec.ipam(), spec.EnableIPv6); err != nil {
+		return err
+	}
+
+	prefixes := spec.prefixes()
+	for i := range prefixes {
+		for j := i + 1; j < len(prefixes); j++ {
+			if prefixes[i].Overlaps(prefixes[j]) {
+				return fmt.Errorf("subnets %s and %s overlap", prefixes[i], prefixes[j])
+			}
+		}
+	}
+	return nil
+}
+
+// checkOverlaps rejects subnets that overlap with the subnets of existing
+// networks, which the daemon only partly checks depending on the driver.
+func (spec NetworkSpec) checkOverlaps(networks []network.Summary) error {
+	for _, prefix := range spec.prefixes() {
+		for _, existing := range networks {
+			for _, config := range existing.IPAM.Config {
+				used, err := netip.ParsePrefix(config.Subnet)
+				if err != nil {
+					continue
+				}
+				if prefix.Overlaps(used) {
+					return fmt.Errorf("subnet %s overlaps with %s of network %s", prefix, used, existing.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (spec NetworkSpec) driver() string {
+	if spec.Driver == "" {
+		return "bridge"
+	}
+	return spec.Driver
+}
+
+func (spec NetworkSpec) ipam() *network.IPAM {
+	ipam := &network.IPAM{Driver: "default"}
+	for _, subnet := range spec.Subnets {
+		ipam.Config = append(ipam.Config, network.IPAMConfig{
+			Subnet:  subnet.Subnet,
+			Gateway: subnet.Gateway,
+			IPRange: subnet.IPRange,
+		})
+	}
+	return ipam
+}
+
+func (spec NetworkSpec) prefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		if prefix, err := netip.ParsePrefix(subnet.Subnet); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		}
+	}
+	return prefixes
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+)
+
+type DockerRegistryService struct {
+	DockerBaseService
+}
+
+type RegistryLoginInfo struct {
+	ServerAddress string `json:"serverAddress"`
+	Username      string `json:"username"`
+	Store         string `json:"store"`
+}
+
+func NewDockerRegistryService() *DockerRegistryService {
+	return &DockerRegistryService{}
+}
+
+func StartupDockerRegistryService(s *DockerRegistryService, ctx context.Context, cli *client.Client) {
+	s.ctx = ctx
+	s.cli = cli
+}
+
+func (s *DockerRegistryService) List() ([]RegistryLoginInfo, error) {
+	cfg, err := loadDockerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	list := []RegistryLoginInfo{}
+	for _, server := range cfg.servers() {
+		info := RegistryLoginInfo{
+			ServerAddress: server,
+			Store:         cfg.helper(server),
+		}
+		if auth, err := cfg.get(server); err == nil {
+			info.Username = auth.Username
+		}
+		if info.Store == "" {
+			info.Store = "file"
+		}
+		list = append(list, info)
+	}
+	return list, nil
+}
+
+// Login validates the credentials against the registry and stores them the
+// same way docker login does. It returns the status reported by the daemon.
+func (s *DockerRegistryService) Login(server string, username string, password string) (string, error) {
+	if s.cli == nil || s.ctx == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	if username == "" || password == "" {
+		return "", fmt.Errorf("username and password are required")
+	}
+	server = registryForDomain(registryHostname(strings.TrimSpace(server)))
+
+	auth := registry.AuthConfig{
+		Username:      username,
+		Password:      password,
+		ServerAddress: server,
+	}
+	resp, err := s.cli.RegistryLogin(s.ctx, auth)
+	if err != nil {
+		return "", fmt.Errorf("login failed: %v", err)
+	}
+	if resp.IdentityToken != "" {
+		auth.Password = ""
+		auth.IdentityToken = resp.IdentityToken
+	}
+
+	cfg, err := loadDockerConfig()
+	if err != nil {
+		return "", err
+	}
+	if err := cfg.store(auth); err != nil {
+		return "", fmt.Errorf("failed to store credentials: %v", err)
+	}
+	return resp.Status, nil
+}
+
+func (s *DockerRegistryService) Logout(server string) error {
+	cfg, err := loadDockerConfig()
+	if err != nil {
+		return err
+	}
+	return cfg.erase(registryForDomain(registryHostname(strings.TrimSpace(server))))
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+type DockerStatsService struct {
+	DockerBaseService
+	streams map[string]*statsStream
+	mu      sync.Mutex
+}
+
+type statsStream struct {
+	cancel context.CancelFunc
+}
+
+type ContainerStats struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpuPercent"`
+	MemoryUsage   uint64  `json:"memoryUsage"`
+	MemoryLimit   uint64  `json:"memoryLimit"`
+	MemoryPercent float64 `json:"memoryPercent"`
+	NetworkRx     uint64  `json:"networkRx"`
+	NetworkTx     uint64  `json:"networkTx"`
+	BlockRead     uint64  `json:"blockRead"`
+	BlockWrite    uint64  `json:"blockWrite"`
+	PIDs          uint64  `json:"pids"`
+	ReadAt        string  `json:"readAt"`
+}
+
+func NewDockerStatsService() *DockerStatsService {
+	return &DockerStatsService{
+		streams: make(map[string]*statsStream),
+	}
+}
+
+func StartupDockerStatsService(s *DockerStatsService, ctx context.Context, cli *client.Client) {
+	s.ctx = ctx
+	s.cli = cli
+}
+
+// StartWatching streams stats of the given container as "docker:stats" events
+// until StopWatching is called or the container stops.
+func (s *DockerStatsService) StartWatching(id string) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+
+	s.mu.Lock()
+	// Don't start multiple listeners for the same container
+	if _, ok := s.streams[id]; ok {
+		s.mu.Unlock()
+		return nil
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	stream := &statsStream{cancel: cancel}
+	s.streams[id] = stream
+	s.mu.Unlock()
+
+	// The stream is registered first and opened outside the lock, so that
+	// waiting for the daemon does not block the other containers
+	resp, err := s.cli.ContainerStats(ctx, id, true)
+	if err != nil {
+		s.release(id, stream)
+		return fmt.Errorf("failed to get container stats: %v", err)
+	}
+
+	go func() {
+		defer resp.Body.Close()
+		defer s.release(id, stream)
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var stats container.StatsResponse
+			if err := decoder.Decode(&stats); err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				runtime.EventsEmit(s.ctx, "docker:stats", s.formatStats(id, resp.OSType, stats))
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *DockerStatsService) StopWatching(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stream, ok := s.streams[id]; ok {
+		stream.cancel()
+		delete(s.streams, id)
+	}
+}
+
+func (s *DockerStatsService) StopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, stream := range s.streams {
+		stream.cancel()
+		delete(s.streams, id)
+	}
+}
+
+func (s *DockerStatsService) Watched() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.streams))
+	for id := range s.streams {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// release forgets a stream that ended on its own, unless it was already
+// replaced by a newer one for the same container.
+func (s *DockerStatsService) release(id string, stream *statsStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stream.cancel()
+	if s.streams[id] == stream {
+		delete(s.streams, id)
+	}
+}
+
+func (s *DockerStatsService) formatStats(id string, osType string, stats container.StatsResponse) ContainerStats {
+	result := ContainerStats{
+		ID:     id,
+		Name:   strings.TrimPrefix(stats.Name, "/"),
+		ReadAt: stats.Read.Format(time.RFC3339),
+	}
+
+	if osType == "windows" {
+		result.CPUPercent = calculateCPUPercentWindows(stats)
+		result.MemoryUsage = stats.MemoryStats.PrivateWorkingSet
+		result.BlockRead = stats.StorageStats.ReadSizeBytes
+		result.BlockWrite = stats.StorageStats.WriteSizeBytes
+		result.PIDs = uint64(stats.NumProcs)
+	} else {
+		result.CPUPercent = calculateCPUPercentUnix(stats)
+		result.MemoryUsage = calculateMemUsageUnixNoCache(stats.MemoryStats)
+		result.MemoryLimit = stats.MemoryStats.Limit
+		if result.MemoryLimit > 0 {
+			result.MemoryPercent = float64(result.MemoryUsage) / float64(result.MemoryLimit) * 100.0
+		}
+		result.BlockRead, result.BlockWrite = calculateBlockIO(stats.BlkioStats)
+		result.PIDs = stats.PidsStats.Current
+	}
+
+	for _, network := range stats.Networks {
+		result.NetworkRx += network.RxBytes
+		result.NetworkTx += network.TxBytes
+	}
+
+	return result
+}
+
+func calculateCPUPercentUnix(stats container.StatsResponse) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	return cpuDelta / systemDelta * onlineCPUs * 100.0
+}
+
+func calculateCPUPercentWindows(stats container.StatsResponse) float64 {
+	// Windows reports CPU usage in 100ns intervals
+	possIntervals := uint64(stats.Read.Sub(stats.PreRead).Nanoseconds()) / 100
+	possIntervals *= uint64(stats.NumProcs)
+	if possIntervals == 0 {
+		return 0
+	}
+
+	intervalsUsed := stats.CPUStats.CPUUsage.TotalUsage - stats.PreCPUStats.CPUUsage.TotalUsage
+	return float64(intervalsUsed) / float64(possIntervals) * 100.0
+}
+
+// calculateMemUsageUnixNoCache subtracts the page cache from the memory usage
+// the same way the docker CLI does.
+func calculateMemUsageUnixNoCache(mem container.MemoryStats) uint64 {
+	// cgroup v1
+	if v, ok := mem.Stats["total_inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+	// cgroup v2
+	if v := mem.Stats["inactive_file"]; v < mem.Usage {
+		return mem.Usage - v
+	}
+	return mem.Usage
+}
+
+func calculateBlockIO(blkio container.BlkioStats) (uint64, uint64) {
+	var read, write uint64
+	for _, entry := range blkio.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			read += entry.Value
+		case "write":
+			write += entry.Value
+		}
+	}
+	return read, write
+}
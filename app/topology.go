@@ -0,0 +1,290 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// topologyDebounce groups bursts of events, e.g. a compose project starting,
+// into a single update.
+const topologyDebounce = 500 * time.Millisecond
+
+type DockerTopologyService struct {
+	DockerBaseService
+	cancel context.CancelFunc
+	mu     sync.Mutex
+}
+
+type TopologyGraph struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+}
+
+type TopologyNode struct {
+	ID string `json:"id"`
+	// Type is one of "network", "container", "port" or "project"
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	// State is set for containers
+	State string `json:"state"`
+	// Driver and Subnets are set for networks
+	Driver  string   `json:"driver"`
+	Subnets []string `json:"subnets"`
+}
+
+type TopologyEdge struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Type is "network" for container to network edges, "port" for published
+	// port to container edges and "project" for project to container edges
+	Type        string   `json:"type"`
+	Label       string   `json:"label"`
+	IPv4Address string   `json:"ipv4Address"`
+	IPv6Address string   `json:"ipv6Address"`
+	Aliases     []string `json:"aliases"`
+}
+
+func NewDockerTopologyService() *DockerTopologyService {
+	return &DockerTopologyService{}
+}
+
+func StartupDockerTopologyService(s *DockerTopologyService, ctx context.Context, cli *client.Client) {
+	s.ctx = ctx
+	s.cli = cli
+}
+
+func (s *DockerTopologyService) Graph() (TopologyGraph, error) {
+	if s.cli == nil || s.ctx == nil {
+		return TopologyGraph{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	networks, err := s.cli.NetworkList(s.ctx, network.ListOptions{})
+	if err != nil {
+		return TopologyGraph{}, fmt.Errorf("failed to list networks: %v", err)
+	}
+	containers, err := s.cli.ContainerList(s.ctx, container.ListOptions{All: true})
+	if err != nil {
+		return TopologyGraph{}, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	return buildTopology(networks, containers), nil
+}
+
+// StartWatching emits the graph as "docker:topology" events whenever a
+// container or network changes.
+func (s *DockerTopologyService) StartWatching() error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Don't start multiple listeners
+	if s.cancel != nil {
+		return nil
+	}
+
+	s.sendUpdate()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.cancel = cancel
+
+	go func() {
+		eventFilter := filters.NewArgs()
+		eventFilter.Add("type", "container")
+		eventFilter.Add("type", "network")
+		eventsChan, errs := s.cli.Events(ctx, events.ListOptions{
+			Filters: eventFilter,
+		})
+
+		var pending <-chan time.Time
+		for {
+			select {
+			case event := <-eventsChan:
+				if isTopologyEvent(event) && pending == nil {
+					pending = time.After(topologyDebounce)
+				}
+			case <-pending:
+				pending = nil
+				s.sendUpdate()
+			case err := <-errs:
+				if err != nil {
+					time.Sleep(2 * time.Second)
+					s.StopWatching() // stop on error
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *DockerTopologyService) StopWatching() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+func (s *DockerTopologyService) sendUpdate() {
+	graph, err := s.Graph()
+	if err != nil {
+		return
+	}
+	runtime.EventsEmit(s.ctx, "docker:topology", graph)
+}
+
+// isTopologyEvent filters out container events that do not change the graph,
+// such as exec sessions and health checks.
+func isTopologyEvent(event events.Message) bool {
+	if event.Type == events.NetworkEventType {
+		return true
+	}
+	switch event.Action {
+	case events.ActionCreate, events.ActionDestroy, events.ActionStart, events.ActionStop,
+		events.ActionDie, events.ActionPause, events.ActionUnPause, events.ActionRename:
+		return true
+	}
+	return false
+}
+
+func buildTopology(networks []network.Summary, containers []container.Summary) TopologyGraph {
+	graph := TopologyGraph{Nodes: []TopologyNode{}, Edges: []TopologyEdge{}}
+	seen := make(map[string]bool)
+	addNode := func(node TopologyNode) {
+		if !seen[node.ID] {
+			seen[node.ID] = true
+			graph.Nodes = append(graph.Nodes, node)
+		}
+	}
+
+	networkNodes := make(map[string]string, len(networks))
+	for _, n := range networks {
+		node := TopologyNode{
+			ID:      "network:" + n.ID,
+			Type:    "network",
+			Label:   n.Name,
+			Driver:  n.Driver,
+			Subnets: []string{},
+		}
+		for _, config := range n.IPAM.Config {
+			if config.Subnet != "" {
+				node.Subnets = append(node.Subnets, config.Subnet)
+			}
+		}
+		networkNodes[n.ID] = node.ID
+		addNode(node)
+	}
+
+	for _, c := range containers {
+		if _, ok := c.Labels[volumeHelperLabel]; ok {
+			continue
+		}
+		containerID := "container:" + c.ID
+		addNode(TopologyNode{
+			ID:    containerID,
+			Type:  "container",
+			Label: containerName(c),
+			State: c.State,
+		})
+
+		if project := c.Labels[composeProjectLabel]; project != "" {
+			projectID := "project:" + project
+			addNode(TopologyNode{ID: projectID, Type: "project", Label: project})
+			graph.Edges = append(graph.Edges, TopologyEdge{
+				ID:      projectID + "->" + containerID,
+				Source:  projectID,
+				Target:  containerID,
+				Type:    "project",
+				Label:   c.Labels[composeServiceLabel],
+				Aliases: []string{},
+			})
+		}
+
+		for _, port := range c.Ports {
+			if port.PublicPort == 0 {
+				continue
+			}
+			hostIP := port.IP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			portID := fmt.Sprintf("port:%s:%d/%s", hostIP, port.PublicPort, port.Type)
+			addNode(TopologyNode{
+				ID:    portID,
+				Type:  "port",
+				Label: fmt.Sprintf("%s:%d/%s", formatHostIP(hostIP), port.PublicPort, port.Type),
+			})
+			graph.Edges = append(graph.Edges, TopologyEdge{
+				ID:      portID + "->" + containerID,
+				Source:  portID,
+				Target:  containerID,
+				Type:    "port",
+				Label:   fmt.Sprintf("%d->%d/%s", port.PublicPort, port.PrivatePort, port.Type),
+				Aliases: []string{},
+			})
+		}
+
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for name, endpoint := range c.NetworkSettings.Networks {
+			if endpoint == nil {
+				continue
+			}
+			networkID, ok := networkNodes[endpoint.NetworkID]
+			if !ok {
+				continue
+			}
+			aliases := endpoint.Aliases
+			if aliases == nil {
+				aliases = []string{}
+			}
+			graph.Edges = append(graph.Edges, TopologyEdge{
+				ID:          containerID + "->" + networkID,
+				Source:      containerID,
+				Target:      networkID,
+				Type:        "network",
+				Label:       name,
+				IPv4Address: endpoint.IPAddress,
+				IPv6Address: endpoint.GlobalIPv6Address,
+				Aliases:     aliases,
+			})
+		}
+	}
+
+	// Keep the order stable so that the frontend layout does not jump
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		return graph.Edges[i].ID < graph.Edges[j].ID
+	})
+	return graph
+}
+
+func formatHostIP(ip string) string {
+	if strings.Contains(ip, ":") {
+		return "[" + ip + "]"
+	}
+	return ip
+}
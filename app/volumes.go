@@ -3,16 +3,30 @@ package app
 import (
 	"context"
 	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+var volumeNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// volumesDebounce groups bursts of events, e.g. a compose project starting,
+// into a single update.
+const volumesDebounce = 500 * time.Millisecond
+
 type DockerVolumesService struct {
 	DockerBaseService
 	cancel context.CancelFunc
@@ -20,11 +34,25 @@ type DockerVolumesService struct {
 }
 
 type VolumeInfo struct {
-	ID        string   `json:"id"`
-	Name      string   `json:"name"`
-	Size      int64    `json:"size"`
-	Tags      []string `json:"tags"`
-	CreatedAt string   `json:"createdAt"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Size and RefCount are -1 when the driver does not report them
+	Size       int64             `json:"size"`
+	RefCount   int64             `json:"refCount"`
+	Tags       []string          `json:"tags"`
+	Driver     string            `json:"driver"`
+	CreatedAt  string            `json:"createdAt"`
+	Containers []VolumeContainer `json:"containers"`
+	// Orphaned is set when no container, running or not, mounts the volume
+	Orphaned bool `json:"orphaned"`
+}
+
+type VolumeContainer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Destination string `json:"destination"`
+	ReadOnly    bool   `json:"readOnly"`
 }
 
 func NewDockerVolumesService() *DockerVolumesService {
@@ -40,12 +68,68 @@ func (s *DockerVolumesService) List() ([]VolumeInfo, error) {
 	if s.cli == nil || s.ctx == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
-	list, err := s.cli.VolumeList(s.ctx, volume.ListOptions{})
+	return s.list()
+}
+
+// list reads the volumes through the disk usage API, which unlike VolumeList
+// reports their sizes and reference counts.
+func (s *DockerVolumesService) list() ([]VolumeInfo, error) {
+	usage, err := s.cli.DiskUsage(s.ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.VolumeObject},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list volumes: %v", err)
 	}
+	containers, err := s.volumeContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.formatList(usage.Volumes, containers)
+	return result, nil
+}
+
+// listWithoutSizes is the cheaper variant of list used on events. Computing
+// the sizes makes the daemon walk every volume, so they are left at -1 and
+// only read when List is called.
+func (s *DockerVolumesService) listWithoutSizes() ([]VolumeInfo, error) {
+	resp, err := s.cli.VolumeList(s.ctx, volume.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %v", err)
+	}
+	containers, err := s.volumeContainers()
+	if err != nil {
+		return nil, err
+	}
 
-	result := s.formatList(list)
+	return s.formatList(resp.Volumes, containers), nil
+}
+
+// volumeContainers maps volume names to the containers mounting them.
+func (s *DockerVolumesService) volumeContainers() (map[string][]VolumeContainer, error) {
+	containers, err := s.cli.ContainerList(s.ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	result := make(map[string][]VolumeContainer)
+	for _, c := range containers {
+		if _, ok := c.Labels[volumeHelperLabel]; ok {
+			continue
+		}
+		for _, m := range c.Mounts {
+			if m.Type != mount.TypeVolume || m.Name == "" {
+				continue
+			}
+			result[m.Name] = append(result[m.Name], VolumeContainer{
+				ID:          c.ID,
+				Name:        containerName(c),
+				State:       c.State,
+				Destination: m.Destination,
+				ReadOnly:    !m.RW,
+			})
+		}
+	}
 	return result, nil
 }
 
@@ -70,16 +154,25 @@ func (s *DockerVolumesService) StartWatching() error {
 	go func() {
 		eventFilter := filters.NewArgs()
 		eventFilter.Add("type", "volume")
+		// Containers being created or removed change the reference counts
+		eventFilter.Add("type", "container")
+		for _, action := range []string{"create", "destroy", "mount", "unmount", "prune"} {
+			eventFilter.Add("event", action)
+		}
 		eventsChan, errs := s.cli.Events(ctx, events.ListOptions{
 			Filters: eventFilter,
 		})
 
+		var pending <-chan time.Time
 		for {
 			select {
-			case event := <-eventsChan:
-				if event.Type == events.VolumeEventType {
-					s.sendListUpdate()
+			case <-eventsChan:
+				if pending == nil {
+					pending = time.After(volumesDebounce)
 				}
+			case <-pending:
+				pending = nil
+				s.sendListUpdate()
 			case err := <-errs:
 				if err != nil {
 					time.Sleep(2 * time.Second)
@@ -105,27 +198,141 @@ func (s *DockerVolumesService) StopWatching() {
 	}
 }
 
-func (s *DockerVolumesService) formatList(volumes volume.ListResponse) []VolumeInfo {
-	list := make([]VolumeInfo, 0, len(volumes.Volumes))
-	for _, vol := range volumes.Volumes {
-		list = append(list, VolumeInfo{
-			Name:      vol.Name,
-			CreatedAt: vol.CreatedAt,
-		})
+func (s *DockerVolumesService) formatList(volumes []*volume.Volume, containers map[string][]VolumeContainer) []VolumeInfo {
+	list := make([]VolumeInfo, 0, len(volumes))
+	for _, vol := range volumes {
+		list = append(list, s.formatVolume(vol, containers[vol.Name]))
 	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Size != list[j].Size {
+			return list[i].Size > list[j].Size
+		}
+		return list[i].Name < list[j].Name
+	})
 	return list
 }
 
+func (s *DockerVolumesService) formatVolume(vol *volume.Volume, containers []VolumeContainer) VolumeInfo {
+	info := VolumeInfo{
+		ID:         vol.Name,
+		Name:       vol.Name,
+		Size:       -1,
+		RefCount:   -1,
+		Tags:       make([]string, 0, len(vol.Labels)),
+		Driver:     vol.Driver,
+		CreatedAt:  vol.CreatedAt,
+		Containers: containers,
+		Orphaned:   len(containers) == 0,
+	}
+	if vol.UsageData != nil {
+		info.Size = vol.UsageData.Size
+		info.RefCount = vol.UsageData.RefCount
+	}
+	if info.Containers == nil {
+		info.Containers = []VolumeContainer{}
+	}
+	for key, value := range vol.Labels {
+		info.Tags = append(info.Tags, key+"="+value)
+	}
+	sort.Strings(info.Tags)
+	return info
+}
+
 func (s *DockerVolumesService) sendListUpdate() {
-	list, err := s.cli.VolumeList(s.ctx, volume.ListOptions{})
+	result, err := s.listWithoutSizes()
 	if err != nil {
 		return
 	}
 
-	result := s.formatList(list)
 	runtime.EventsEmit(s.ctx, "docker:volumes", result)
 }
 
+// Create creates a volume. For the local driver the options follow the mount
+// command: "type", "device" and "o", e.g. type=nfs, o=addr=10.0.0.1,rw and
+// device=:/export for NFS, type=tmpfs and o=size=100m for tmpfs, or type=none,
+// o=bind and device=/host/path for a bind-style volume.
+func (s *DockerVolumesService) Create(name string, driver string, driverOpts map[string]string, labels map[string]string) (VolumeInfo, error) {
+	if s.cli == nil || s.ctx == nil {
+		return VolumeInfo{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	name = strings.TrimSpace(name)
+	if name != "" && !volumeNameRegexp.MatchString(name) {
+		return VolumeInfo{}, fmt.Errorf("invalid volume name %q", name)
+	}
+	if driver == "" {
+		driver = "local"
+	}
+	if driver == "local" {
+		if err := validateLocalVolumeOpts(driverOpts); err != nil {
+			return VolumeInfo{}, err
+		}
+	}
+
+	vol, err := s.cli.VolumeCreate(s.ctx, volume.CreateOptions{
+		Name:       name,
+		Driver:     driver,
+		DriverOpts: driverOpts,
+		Labels:     labels,
+	})
+	if err != nil {
+		return VolumeInfo{}, fmt.Errorf("failed to create volume: %v", err)
+	}
+
+	return s.formatVolume(&vol, nil), nil
+}
+
+func validateLocalVolumeOpts(opts map[string]string) error {
+	for key := range opts {
+		switch key {
+		case "type", "device", "o", "size":
+		default:
+			return fmt.Errorf("unsupported option %q for the local driver", key)
+		}
+	}
+
+	mountType, device, options := opts["type"], opts["device"], opts["o"]
+	if mountType == "" && (device != "" || options != "") {
+		return fmt.Errorf("option \"type\" is required when \"device\" or \"o\" is set")
+	}
+
+	switch mountType {
+	case "":
+	case "tmpfs":
+		if device == "" {
+			opts["device"] = "tmpfs"
+		}
+	case "nfs", "nfs4":
+		if !strings.Contains(options, "addr=") {
+			return fmt.Errorf("NFS volumes require the server address as addr=<host> in \"o\"")
+		}
+		if device == "" {
+			return fmt.Errorf("NFS volumes require the export path in \"device\", e.g. :/export")
+		}
+	case "none":
+		if !hasMountOption(options, "bind") {
+			return fmt.Errorf("type \"none\" requires o=bind")
+		}
+		if !path.IsAbs(filepath.ToSlash(device)) && !filepath.IsAbs(device) {
+			return fmt.Errorf("bind volumes require an absolute host path in \"device\"")
+		}
+	default:
+		if device == "" {
+			return fmt.Errorf("option \"device\" is required for type %q", mountType)
+		}
+	}
+	return nil
+}
+
+func hasMountOption(options string, name string) bool {
+	for _, option := range strings.Split(options, ",") {
+		if option == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *DockerVolumesService) Remove(id string) error {
 	if s.cli == nil || s.ctx == nil {
 		return fmt.Errorf("Docker client not initialized")
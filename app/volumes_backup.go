@@ -0,0 +1,249 @@
+package app
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// VolumeTransferProgress is emitted as "docker:volumes:backup" and
+// "docker:volumes:restore" events. Total is 0 when the size is not known in
+// advance.
+type VolumeTransferProgress struct {
+	Volume string `json:"volume"`
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+	Total  int64  `json:"total"`
+	Done   bool   `json:"done"`
+	Error  string `json:"error"`
+}
+
+// Backup writes the contents of a volume to a gzip compressed tarball chosen
+// with a save dialog. The archive holds the files relative to the volume root,
+// so it can also be restored with plain tar. It returns the archive path.
+func (s *DockerVolumesService) Backup(volumeName string) (string, error) {
+	if s.cli == nil || s.ctx == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Backup Docker Volume",
+		DefaultFilename: fmt.Sprintf("%s-%s.tar.gz", volumeName, time.Now().Format("20060102-150405")),
+		Filters:         []runtime.FileFilter{{DisplayName: "Gzip Files", Pattern: "*.tar.gz;*.tgz"}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("dialog error: %w", err)
+	}
+	if savePath == "" {
+		return "", fmt.Errorf("no path selected")
+	}
+
+	progress := VolumeTransferProgress{Volume: volumeName, Path: savePath}
+	err = s.backup(volumeName, savePath, &progress)
+	s.finishVolumeTransfer("docker:volumes:backup", progress, err)
+	if err != nil {
+		os.Remove(savePath)
+		return "", err
+	}
+	return savePath, nil
+}
+
+func (s *DockerVolumesService) backup(volumeName string, savePath string, progress *VolumeTransferProgress) error {
+	id, err := s.createVolumeHelper(volumeName, nil, true)
+	if err != nil {
+		return err
+	}
+	defer s.removeVolumeHelper(id)
+
+	reader, _, err := s.cli.CopyFromContainer(s.ctx, id, volumeMountPoint)
+	if err != nil {
+		return fmt.Errorf("failed to read volume: %v", err)
+	}
+	defer reader.Close()
+
+	outFile, err := os.Create(savePath)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	gzipWriter := gzip.NewWriter(outFile)
+	counter := s.volumeProgressReader(reader, "docker:volumes:backup", progress)
+	if err := rebaseVolumeArchive(gzipWriter, counter); err != nil {
+		return fmt.Errorf("failed to write backup: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return outFile.Close()
+}
+
+// Restore extracts a tarball created by Backup into a volume, creating the
+// volume when it does not exist. An open dialog is shown when archivePath is
+// empty. A volume that already holds files is refused unless overwrite is
+// set, existing files with the same names are then overwritten.
+func (s *DockerVolumesService) Restore(volumeName string, archivePath string, overwrite bool) error {
+	if s.cli == nil || s.ctx == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	if strings.TrimSpace(volumeName) == "" {
+		return fmt.Errorf("volume name is required")
+	}
+
+	if archivePath == "" {
+		openPath, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+			Title:   "Restore Docker Volume",
+			Filters: []runtime.FileFilter{{DisplayName: "Volume Archives", Pattern: "*.tar;*.tar.gz;*.tgz"}},
+		})
+		if err != nil {
+			return fmt.Errorf("dialog error: %w", err)
+		}
+		if openPath == "" {
+			return fmt.Errorf("no file selected")
+		}
+		archivePath = openPath
+	}
+
+	progress := VolumeTransferProgress{Volume: volumeName, Path: archivePath}
+	err := s.restore(volumeName, archivePath, overwrite, &progress)
+	s.finishVolumeTransfer("docker:volumes:restore", progress, err)
+	return err
+}
+
+func (s *DockerVolumesService) restore(volumeName string, archivePath string, overwrite bool, progress *VolumeTransferProgress) error {
+	inFile, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer inFile.Close()
+
+	if info, err := inFile.Stat(); err == nil {
+		progress.Total = info.Size()
+	}
+
+	if _, err := s.cli.VolumeInspect(s.ctx, volumeName); err != nil {
+		if !cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("failed to inspect volume: %v", err)
+		}
+		if _, err := s.cli.VolumeCreate(s.ctx, volume.CreateOptions{Name: volumeName}); err != nil {
+			return fmt.Errorf("failed to create volume: %v", err)
+		}
+	} else if !overwrite {
+		empty, err := s.volumeIsEmpty(volumeName)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return fmt.Errorf("volume %s is not empty, restoring would overwrite its files", volumeName)
+		}
+	}
+
+	id, err := s.createVolumeHelper(volumeName, nil, false)
+	if err != nil {
+		return err
+	}
+	defer s.removeVolumeHelper(id)
+
+	// The daemon detects the compression itself, the progress is reported
+	// on the bytes read from the file
+	counter := s.volumeProgressReader(inFile, "docker:volumes:restore", progress)
+	if err := s.cli.CopyToContainer(s.ctx, id, volumeMountPoint, counter, container.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("failed to restore volume: %v", err)
+	}
+	return nil
+}
+
+func (s *DockerVolumesService) volumeIsEmpty(volumeName string) (bool, error) {
+	id, err := s.createVolumeHelper(volumeName, []string{"find", volumeMountPoint, "-mindepth", "1", "-maxdepth", "1"}, true)
+	if err != nil {
+		return false, err
+	}
+	defer s.removeVolumeHelper(id)
+
+	output, err := s.runVolumeHelper(id)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) == "", nil
+}
+
+func (s *DockerVolumesService) finishVolumeTransfer(event string, progress VolumeTransferProgress, err error) {
+	progress.Done = true
+	if err != nil {
+		progress.Error = err.Error()
+	}
+	runtime.EventsEmit(s.ctx, event, progress)
+}
+
+// volumeProgressReader counts the bytes read from reader into progress and
+// emits it at most once per progressInterval.
+func (s *DockerVolumesService) volumeProgressReader(reader io.Reader, event string, progress *VolumeTransferProgress) io.Reader {
+	return &progressReader{reader: reader, report: func(n int64) {
+		progress.Bytes = n
+		runtime.EventsEmit(s.ctx, event, *progress)
+	}}
+}
+
+type progressReader struct {
+	reader   io.Reader
+	bytes    int64
+	lastEmit time.Time
+	report   func(n int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytes += int64(n)
+	if time.Since(r.lastEmit) >= progressInterval {
+		r.lastEmit = time.Now()
+		r.report(r.bytes)
+	}
+	return n, err
+}
+
+// rebaseVolumeArchive copies the archive returned for volumeMountPoint,
+// whose entries all start with its base name, so that the entries are
+// relative to the volume root instead. The root itself becomes "./" to keep
+// its mode and owner.
+func rebaseVolumeArchive(dst io.Writer, src io.Reader) error {
+	prefix := path.Base(volumeMountPoint) + "/"
+
+	tr := tar.NewReader(src)
+	tw := tar.NewWriter(dst)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(header.Name, prefix)
+		if name == "" || header.Name == path.Base(volumeMountPoint) {
+			name = "./"
+		}
+		header.Name = name
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = strings.TrimPrefix(header.Linkname, prefix)
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
@@ -0,0 +1,349 @@
+package app
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// volumeHelperImage runs the short-lived containers used to look into
+	// volumes. It is pulled on first use.
+	volumeHelperImage = "busybox:latest"
+	// volumeHelperLabel marks helper containers so they can be told apart
+	// from the user's own containers.
+	volumeHelperLabel = "dockmate.helper"
+	volumeMountPoint  = "/volume"
+	// maxVolumeFileSize is the largest file ReadFile returns in full.
+	maxVolumeFileSize = 1 << 20
+)
+
+// volumeEntryFormat is passed to stat in the helper container. The name comes
+// last so that it may contain the separator.
+const volumeEntryFormat = "%F|%s|%Y|%A|%n"
+
+type DockerVolumeBrowserService struct {
+	DockerBaseService
+}
+
+type VolumeEntry struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	Mode       string `json:"mode"`
+	ModifiedAt string `json:"modifiedAt"`
+	LinkTarget string `json:"linkTarget"`
+}
+
+type VolumeFileContent struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated"`
+	Binary    bool   `json:"binary"`
+}
+
+func NewDockerVolumeBrowserService() *DockerVolumeBrowserService {
+	return &DockerVolumeBrowserService{}
+}
+
+func StartupDockerVolumeBrowserService(s *DockerVolumeBrowserService, ctx context.Context, cli *client.Client) {
+	s.ctx = ctx
+	s.cli = cli
+}
+
+// List returns the entries of a directory inside a volume, directories first.
+// Paths are relative to the volume root.
+func (s *DockerVolumeBrowserService) List(volumeName string, dir string) ([]VolumeEntry, error) {
+	if s.cli == nil || s.ctx == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	target := volumePath(dir)
+	id, err := s.createVolumeHelper(volumeName, []string{
+		"find", target, "-mindepth", "1", "-maxdepth", "1",
+		"-exec", "stat", "-c", volumeEntryFormat, "{}", "+",
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	defer s.removeVolumeHelper(id)
+
+	stat, err := s.cli.ContainerStatPath(s.ctx, id, target)
+	if err != nil {
+		return nil, volumePathError(dir, err)
+	}
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", relativeVolumePath(target))
+	}
+
+	output, err := s.runVolumeHelper(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []VolumeEntry{}
+	for _, line := range strings.Split(output, "\n") {
+		if entry, ok := parseVolumeEntry(line); ok {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if (entries[i].Type == "dir") != (entries[j].Type == "dir") {
+			return entries[i].Type == "dir"
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+func (s *DockerVolumeBrowserService) Stat(volumeName string, filePath string) (VolumeEntry, error) {
+	if s.cli == nil || s.ctx == nil {
+		return VolumeEntry{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	id, err := s.createVolumeHelper(volumeName, nil, true)
+	if err != nil {
+		return VolumeEntry{}, err
+	}
+	defer s.removeVolumeHelper(id)
+
+	target := volumePath(filePath)
+	stat, err := s.cli.ContainerStatPath(s.ctx, id, target)
+	if err != nil {
+		return VolumeEntry{}, volumePathError(filePath, err)
+	}
+
+	return VolumeEntry{
+		Name:       path.Base(target),
+		Path:       relativeVolumePath(target),
+		Type:       fileModeType(stat.Mode),
+		Size:       stat.Size,
+		Mode:       stat.Mode.String(),
+		ModifiedAt: stat.Mtime.Format(time.RFC3339),
+		LinkTarget: stat.LinkTarget,
+	}, nil
+}
+
+// ReadFile returns the contents of a file inside a volume. Files larger than
+// maxVolumeFileSize are truncated and binary files are returned without
+// content.
+func (s *DockerVolumeBrowserService) ReadFile(volumeName string, filePath string) (VolumeFileContent, error) {
+	if s.cli == nil || s.ctx == nil {
+		return VolumeFileContent{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	id, err := s.createVolumeHelper(volumeName, nil, true)
+	if err != nil {
+		return VolumeFileContent{}, err
+	}
+	defer s.removeVolumeHelper(id)
+
+	target := volumePath(filePath)
+	reader, stat, err := s.cli.CopyFromContainer(s.ctx, id, target)
+	if err != nil {
+		return VolumeFileContent{}, volumePathError(filePath, err)
+	}
+	defer reader.Close()
+
+	if !stat.Mode.IsRegular() {
+		return VolumeFileContent{}, fmt.Errorf("%s is not a regular file", relativeVolumePath(target))
+	}
+
+	tr := tar.NewReader(reader)
+	if _, err := tr.Next(); err != nil {
+		return VolumeFileContent{}, fmt.Errorf("failed to read file: %v", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(tr, maxVolumeFileSize))
+	if err != nil {
+		return VolumeFileContent{}, fmt.Errorf("failed to read file: %v", err)
+	}
+
+	content := VolumeFileContent{
+		Path:      relativeVolumePath(target),
+		Size:      stat.Size,
+		Truncated: stat.Size > maxVolumeFileSize,
+	}
+	if isBinary(data, content.Truncated) {
+		content.Binary = true
+		return content, nil
+	}
+	content.Content = string(data)
+	return content, nil
+}
+
+// createVolumeHelper creates, without starting, a container that has the
+// volume mounted at volumeMountPoint.
+func (s *DockerBaseService) createVolumeHelper(volumeName string, cmd []string, readOnly bool) (string, error) {
+	if _, err := s.cli.VolumeInspect(s.ctx, volumeName); err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return "", fmt.Errorf("volume %s not found", volumeName)
+		}
+		return "", fmt.Errorf("failed to inspect volume: %v", err)
+	}
+	if err := s.ensureImage(volumeHelperImage); err != nil {
+		return "", err
+	}
+
+	resp, err := s.cli.ContainerCreate(s.ctx, &container.Config{
+		Image:           volumeHelperImage,
+		Cmd:             cmd,
+		Labels:          map[string]string{volumeHelperLabel: "volume-browser"},
+		NetworkDisabled: true,
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{{
+			Type:     mount.TypeVolume,
+			Source:   volumeName,
+			Target:   volumeMountPoint,
+			ReadOnly: readOnly,
+		}},
+	}, nil, nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create helper container: %v", err)
+	}
+	return resp.ID, nil
+}
+
+// runVolumeHelper starts a helper container, waits for it to exit and returns
+// what it wrote to stdout.
+func (s *DockerBaseService) runVolumeHelper(id string) (string, error) {
+	if err := s.cli.ContainerStart(s.ctx, id, container.StartOptions{}); err != nil {
+		return "", fmt.Errorf("failed to start helper container: %v", err)
+	}
+
+	var exitCode int64
+	waitCh, errCh := s.cli.ContainerWait(s.ctx, id, container.WaitConditionNotRunning)
+	select {
+	case result := <-waitCh:
+		exitCode = result.StatusCode
+	case err := <-errCh:
+		return "", fmt.Errorf("failed to wait for helper container: %v", err)
+	}
+
+	logs, err := s.cli.ContainerLogs(s.ctx, id, container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to read helper output: %v", err)
+	}
+	defer logs.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, logs); err != nil {
+		return "", fmt.Errorf("failed to read helper output: %v", err)
+	}
+	// find exits with 1 when some entries could not be read but still lists
+	// the others
+	if exitCode != 0 && stdout.Len() == 0 {
+		return "", fmt.Errorf("helper container failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func (s *DockerBaseService) removeVolumeHelper(id string) {
+	// Use a fresh context so the helper is removed even when the app context
+	// is being cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.cli.ContainerRemove(ctx, id, container.RemoveOptions{Force: true})
+}
+
+// volumePath maps a path relative to the volume root to the path inside the
+// helper container, without allowing it to escape the mount point.
+func volumePath(p string) string {
+	return path.Join(volumeMountPoint, path.Clean("/"+p))
+}
+
+func relativeVolumePath(p string) string {
+	rel := strings.TrimPrefix(p, volumeMountPoint)
+	if rel == "" {
+		return "/"
+	}
+	return rel
+}
+
+func volumePathError(p string, err error) error {
+	if cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("%s does not exist", path.Clean("/"+p))
+	}
+	return fmt.Errorf("failed to access %s: %v", path.Clean("/"+p), err)
+}
+
+func parseVolumeEntry(line string) (VolumeEntry, bool) {
+	fields := strings.SplitN(line, "|", 5)
+	if len(fields) != 5 {
+		return VolumeEntry{}, false
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return VolumeEntry{}, false
+	}
+	mtime, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return VolumeEntry{}, false
+	}
+
+	entry := VolumeEntry{
+		Name:       path.Base(fields[4]),
+		Path:       relativeVolumePath(fields[4]),
+		Type:       statFileType(fields[0]),
+		Size:       size,
+		Mode:       fields[3],
+		ModifiedAt: time.Unix(mtime, 0).Format(time.RFC3339),
+	}
+	return entry, true
+}
+
+// statFileType maps the file type printed by stat's %F to the type names
+// used by VolumeEntry.
+func statFileType(fileType string) string {
+	switch fileType {
+	case "directory":
+		return "dir"
+	case "regular file", "regular empty file":
+		return "file"
+	case "symbolic link":
+		return "symlink"
+	default:
+		return "other"
+	}
+}
+
+func fileModeType(mode os.FileMode) string {
+	switch {
+	case mode.IsDir():
+		return "dir"
+	case mode.IsRegular():
+		return "file"
+	case mode&os.ModeSymlink != 0:
+		return "symlink"
+	default:
+		return "other"
+	}
+}
+
+// isBinary reports whether data does not look like text. A truncated file
+// may end in the middle of a multi-byte character, which is allowed.
+func isBinary(data []byte, truncated bool) bool {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return true
+	}
+	if truncated && len(data) > utf8.UTFMax {
+		data = data[:len(data)-utf8.UTFMax]
+	}
+	return !utf8.Valid(data)
+}
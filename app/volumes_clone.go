@@ -0,0 +1,168 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// Clone creates the volume dst with the driver, options and labels of src and
+// copies the data of src into it. It refuses to run while a container using
+// src is running, as the copy could be inconsistent, and for volumes whose
+// options point to shared storage, as the clone would be the same data.
+func (s *DockerVolumesService) Clone(src string, dst string) (VolumeInfo, error) {
+	if s.cli == nil || s.ctx == nil {
+		return VolumeInfo{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	source, err := s.prepareClone(src, dst)
+	if err != nil {
+		return VolumeInfo{}, err
+	}
+	if sharesStorage(source) {
+		return VolumeInfo{}, fmt.Errorf("volume %s is backed by device %s, a clone would share its data", src, source.Options["device"])
+	}
+	containers, err := s.volumeContainers()
+	if err != nil {
+		return VolumeInfo{}, err
+	}
+	if running := runningVolumeContainers(containers[src]); len(running) > 0 {
+		return VolumeInfo{}, fmt.Errorf("volume %s is used by running containers: %s", src, strings.Join(running, ", "))
+	}
+
+	return s.clone(source, dst, true)
+}
+
+// Rename clones src to dst and removes src. As removing src requires that no
+// container uses it, containers that are not running are refused as well.
+// Volumes on shared storage are not copied, dst points to the same data.
+func (s *DockerVolumesService) Rename(src string, dst string) (VolumeInfo, error) {
+	if s.cli == nil || s.ctx == nil {
+		return VolumeInfo{}, fmt.Errorf("Docker client not initialized")
+	}
+
+	source, err := s.prepareClone(src, dst)
+	if err != nil {
+		return VolumeInfo{}, err
+	}
+	containers, err := s.volumeContainers()
+	if err != nil {
+		return VolumeInfo{}, err
+	}
+	if users := containers[src]; len(users) > 0 {
+		names := make([]string, 0, len(users))
+		for _, c := range users {
+			names = append(names, c.Name)
+		}
+		return VolumeInfo{}, fmt.Errorf("volume %s is used by containers: %s", src, strings.Join(names, ", "))
+	}
+
+	info, err := s.clone(source, dst, !sharesStorage(source))
+	if err != nil {
+		return VolumeInfo{}, err
+	}
+	if err := s.cli.VolumeRemove(s.ctx, src, false); err != nil {
+		return info, fmt.Errorf("volume copied to %s but failed to remove %s: %v", dst, src, err)
+	}
+	return info, nil
+}
+
+// prepareClone checks the names and returns the source volume.
+func (s *DockerVolumesService) prepareClone(src string, dst string) (volume.Volume, error) {
+	if dst == "" {
+		return volume.Volume{}, fmt.Errorf("target volume name is required")
+	}
+	if !volumeNameRegexp.MatchString(dst) {
+		return volume.Volume{}, fmt.Errorf("invalid volume name %q", dst)
+	}
+	if src == dst {
+		return volume.Volume{}, fmt.Errorf("source and target volume are the same")
+	}
+
+	source, err := s.cli.VolumeInspect(s.ctx, src)
+	if err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return volume.Volume{}, fmt.Errorf("volume %s not found", src)
+		}
+		return volume.Volume{}, fmt.Errorf("failed to inspect volume: %v", err)
+	}
+	if _, err := s.cli.VolumeInspect(s.ctx, dst); err == nil {
+		return volume.Volume{}, fmt.Errorf("volume %s already exists", dst)
+	} else if !cerrdefs.IsNotFound(err) {
+		return volume.Volume{}, fmt.Errorf("failed to inspect volume: %v", err)
+	}
+	return source, nil
+}
+
+func (s *DockerVolumesService) clone(source volume.Volume, dst string, copyData bool) (VolumeInfo, error) {
+	created, err := s.cli.VolumeCreate(s.ctx, volume.CreateOptions{
+		Name:       dst,
+		Driver:     source.Driver,
+		DriverOpts: source.Options,
+		Labels:     source.Labels,
+	})
+	if err != nil {
+		return VolumeInfo{}, fmt.Errorf("failed to create volume: %v", err)
+	}
+
+	if copyData {
+		if err := s.copyVolume(source.Name, dst); err != nil {
+			s.cli.VolumeRemove(s.ctx, dst, true)
+			return VolumeInfo{}, err
+		}
+	}
+	return s.formatVolume(&created, nil), nil
+}
+
+// copyVolume streams the contents of src into dst. The archive read from
+// the source helper has its entries under the mount point, so extracting it
+// at the root of the target helper puts them into the target volume with
+// their ownership and permissions intact.
+func (s *DockerVolumesService) copyVolume(src string, dst string) error {
+	srcID, err := s.createVolumeHelper(src, nil, true)
+	if err != nil {
+		return err
+	}
+	defer s.removeVolumeHelper(srcID)
+
+	dstID, err := s.createVolumeHelper(dst, nil, false)
+	if err != nil {
+		return err
+	}
+	defer s.removeVolumeHelper(dstID)
+
+	reader, _, err := s.cli.CopyFromContainer(s.ctx, srcID, volumeMountPoint)
+	if err != nil {
+		return fmt.Errorf("failed to read volume %s: %v", src, err)
+	}
+	defer reader.Close()
+
+	if err := s.cli.CopyToContainer(s.ctx, dstID, "/", reader, container.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("failed to copy into volume %s: %v", dst, err)
+	}
+	return nil
+}
+
+// sharesStorage reports whether a volume with the same options points to the
+// same data, as is the case for local volumes backed by a device, a bind
+// mount or a network share. Copying would then write the data onto itself.
+func sharesStorage(vol volume.Volume) bool {
+	if vol.Driver != "local" {
+		return false
+	}
+	device := vol.Options["device"]
+	return device != "" && vol.Options["type"] != "tmpfs"
+}
+
+func runningVolumeContainers(containers []VolumeContainer) []string {
+	var names []string
+	for _, c := range containers {
+		if c.State == "running" {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
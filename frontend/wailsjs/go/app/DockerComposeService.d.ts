@@ -0,0 +1,13 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {app} from '../models';
+
+export function Discover(arg1:string):Promise<Array<string>>;
+
+export function Down(arg1:string,arg2:boolean):Promise<Array<app.ContainerActionResult>>;
+
+export function Load(arg1:string):Promise<app.ComposeProjectInfo>;
+
+export function OpenFile():Promise<string>;
+
+export function Up(arg1:string):Promise<Array<app.ContainerActionResult>>;
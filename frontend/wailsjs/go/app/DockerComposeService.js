@@ -0,0 +1,23 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Discover(arg1) {
+  return window['go']['app']['DockerComposeService']['Discover'](arg1);
+}
+
+export function Down(arg1, arg2) {
+  return window['go']['app']['DockerComposeService']['Down'](arg1, arg2);
+}
+
+export function Load(arg1) {
+  return window['go']['app']['DockerComposeService']['Load'](arg1);
+}
+
+export function OpenFile() {
+  return window['go']['app']['DockerComposeService']['OpenFile']();
+}
+
+export function Up(arg1) {
+  return window['go']['app']['DockerComposeService']['Up'](arg1);
+}
@@ -2,6 +2,8 @@
 // This file is automatically generated. DO NOT EDIT
 import {app} from '../models';
 
+export function Create(arg1:app.ContainerSpec):Promise<app.ContainerCreateResult>;
+
 export function Inspect(arg1:string):Promise<string>;
 
 export function Kill(arg1:string):Promise<void>;
@@ -10,12 +12,20 @@ export function List():Promise<Array<app.ContainersGroup>>;
 
 export function Remove(arg1:string):Promise<void>;
 
+export function RemoveGroup(arg1:string):Promise<Array<app.ContainerActionResult>>;
+
 export function Restart(arg1:string):Promise<void>;
 
+export function RestartGroup(arg1:string):Promise<Array<app.ContainerActionResult>>;
+
 export function Start(arg1:string):Promise<void>;
 
+export function StartGroup(arg1:string):Promise<Array<app.ContainerActionResult>>;
+
 export function StartWatching():Promise<void>;
 
 export function Stop(arg1:string):Promise<void>;
 
+export function StopGroup(arg1:string):Promise<Array<app.ContainerActionResult>>;
+
 export function StopWatching():Promise<void>;
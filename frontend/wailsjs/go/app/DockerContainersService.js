@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function Create(arg1) {
+  return window['go']['app']['DockerContainersService']['Create'](arg1);
+}
+
 export function Inspect(arg1) {
   return window['go']['app']['DockerContainersService']['Inspect'](arg1);
 }
@@ -18,14 +22,26 @@ export function Remove(arg1) {
   return window['go']['app']['DockerContainersService']['Remove'](arg1);
 }
 
+export function RemoveGroup(arg1) {
+  return window['go']['app']['DockerContainersService']['RemoveGroup'](arg1);
+}
+
 export function Restart(arg1) {
   return window['go']['app']['DockerContainersService']['Restart'](arg1);
 }
 
+export function RestartGroup(arg1) {
+  return window['go']['app']['DockerContainersService']['RestartGroup'](arg1);
+}
+
 export function Start(arg1) {
   return window['go']['app']['DockerContainersService']['Start'](arg1);
 }
 
+export function StartGroup(arg1) {
+  return window['go']['app']['DockerContainersService']['StartGroup'](arg1);
+}
+
 export function StartWatching() {
   return window['go']['app']['DockerContainersService']['StartWatching']();
 }
@@ -34,6 +50,10 @@ export function Stop(arg1) {
   return window['go']['app']['DockerContainersService']['Stop'](arg1);
 }
 
+export function StopGroup(arg1) {
+  return window['go']['app']['DockerContainersService']['StopGroup'](arg1);
+}
+
 export function StopWatching() {
   return window['go']['app']['DockerContainersService']['StopWatching']();
 }
@@ -2,16 +2,46 @@
 // This file is automatically generated. DO NOT EDIT
 import {app} from '../models';
 
+export function Build(arg1:app.ImageBuildSpec):Promise<void>;
+
+export function CancelBuild(arg1:string):Promise<void>;
+
+export function CancelPull(arg1:string):Promise<void>;
+
+export function CancelPush(arg1:string):Promise<void>;
+
 export function CreateAndStart(arg1:string):Promise<void>;
 
+export function History(arg1:string):Promise<app.ImageHistory>;
+
 export function Inspect(arg1:string):Promise<string>;
 
 export function List():Promise<Array<app.ImageInfo>>;
 
-export function Remove(arg1:string):Promise<void>;
+export function Load():Promise<Array<string>>;
+
+export function PreviewPrune(arg1:app.ImagePruneOptions):Promise<app.ImagePrunePreview>;
+
+export function Prune(arg1:app.ImagePruneOptions):Promise<app.ImagePruneReport>;
+
+export function PruneSelected(arg1:Array<string>):Promise<app.ImagePruneReport>;
+
+export function Pull(arg1:string):Promise<void>;
+
+export function Push(arg1:string):Promise<void>;
+
+export function Remove(arg1:string,arg2:boolean):Promise<app.ImageRemoveResult>;
+
+export function Retag(arg1:string,arg2:string):Promise<void>;
 
 export function Save(arg1:string):Promise<void>;
 
+export function Search(arg1:string,arg2:number):Promise<Array<app.ImageSearchResult>>;
+
+export function SelectBuildContext():Promise<string>;
+
 export function StartWatching():Promise<void>;
 
 export function StopWatching():Promise<void>;
+
+export function Tag(arg1:string,arg2:string):Promise<void>;
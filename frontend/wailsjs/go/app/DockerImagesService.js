@@ -2,10 +2,30 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function Build(arg1) {
+  return window['go']['app']['DockerImagesService']['Build'](arg1);
+}
+
+export function CancelBuild(arg1) {
+  return window['go']['app']['DockerImagesService']['CancelBuild'](arg1);
+}
+
+export function CancelPull(arg1) {
+  return window['go']['app']['DockerImagesService']['CancelPull'](arg1);
+}
+
+export function CancelPush(arg1) {
+  return window['go']['app']['DockerImagesService']['CancelPush'](arg1);
+}
+
 export function CreateAndStart(arg1) {
   return window['go']['app']['DockerImagesService']['CreateAndStart'](arg1);
 }
 
+export function History(arg1) {
+  return window['go']['app']['DockerImagesService']['History'](arg1);
+}
+
 export function Inspect(arg1) {
   return window['go']['app']['DockerImagesService']['Inspect'](arg1);
 }
@@ -14,14 +34,50 @@ export function List() {
   return window['go']['app']['DockerImagesService']['List']();
 }
 
-export function Remove(arg1) {
-  return window['go']['app']['DockerImagesService']['Remove'](arg1);
+export function Load() {
+  return window['go']['app']['DockerImagesService']['Load']();
+}
+
+export function PreviewPrune(arg1) {
+  return window['go']['app']['DockerImagesService']['PreviewPrune'](arg1);
+}
+
+export function Prune(arg1) {
+  return window['go']['app']['DockerImagesService']['Prune'](arg1);
+}
+
+export function PruneSelected(arg1) {
+  return window['go']['app']['DockerImagesService']['PruneSelected'](arg1);
+}
+
+export function Pull(arg1) {
+  return window['go']['app']['DockerImagesService']['Pull'](arg1);
+}
+
+export function Push(arg1) {
+  return window['go']['app']['DockerImagesService']['Push'](arg1);
+}
+
+export function Remove(arg1, arg2) {
+  return window['go']['app']['DockerImagesService']['Remove'](arg1, arg2);
+}
+
+export function Retag(arg1, arg2) {
+  return window['go']['app']['DockerImagesService']['Retag'](arg1, arg2);
 }
 
 export function Save(arg1) {
   return window['go']['app']['DockerImagesService']['Save'](arg1);
 }
 
+export function Search(arg1, arg2) {
+  return window['go']['app']['DockerImagesService']['Search'](arg1, arg2);
+}
+
+export function SelectBuildContext() {
+  return window['go']['app']['DockerImagesService']['SelectBuildContext']();
+}
+
 export function StartWatching() {
   return window['go']['app']['DockerImagesService']['StartWatching']();
 }
@@ -29,3 +85,7 @@ export function StartWatching() {
 export function StopWatching() {
   return window['go']['app']['DockerImagesService']['StopWatching']();
 }
+
+export function Tag(arg1, arg2) {
+  return window['go']['app']['DockerImagesService']['Tag'](arg1, arg2);
+}
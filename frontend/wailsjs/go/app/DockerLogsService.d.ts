@@ -1,6 +1,15 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {app} from '../models';
 
-export function StartWatching(arg1:string):Promise<void>;
+export function Fetch(arg1:Array<string>,arg2:app.LogQuery):Promise<Array<app.LogEntry>>;
 
-export function StopWatching():Promise<void>;
+export function StartProjectWatching(arg1:string,arg2:string,arg3:app.LogQuery):Promise<void>;
+
+export function StartWatching(arg1:string,arg2:Array<string>,arg3:app.LogQuery):Promise<void>;
+
+export function StopAll():Promise<void>;
+
+export function StopWatching(arg1:string):Promise<void>;
+
+export function Watched():Promise<Array<string>>;
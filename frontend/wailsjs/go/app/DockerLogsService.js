@@ -2,10 +2,26 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function StartWatching(arg1) {
-  return window['go']['app']['DockerLogsService']['StartWatching'](arg1);
+export function Fetch(arg1, arg2) {
+  return window['go']['app']['DockerLogsService']['Fetch'](arg1, arg2);
 }
 
-export function StopWatching() {
-  return window['go']['app']['DockerLogsService']['StopWatching']();
+export function StartProjectWatching(arg1, arg2, arg3) {
+  return window['go']['app']['DockerLogsService']['StartProjectWatching'](arg1, arg2, arg3);
+}
+
+export function StartWatching(arg1, arg2, arg3) {
+  return window['go']['app']['DockerLogsService']['StartWatching'](arg1, arg2, arg3);
+}
+
+export function StopAll() {
+  return window['go']['app']['DockerLogsService']['StopAll']();
+}
+
+export function StopWatching(arg1) {
+  return window['go']['app']['DockerLogsService']['StopWatching'](arg1);
+}
+
+export function Watched() {
+  return window['go']['app']['DockerLogsService']['Watched']();
 }
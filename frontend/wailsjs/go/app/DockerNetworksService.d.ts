@@ -2,6 +2,12 @@
 // This file is automatically generated. DO NOT EDIT
 import {app} from '../models';
 
+export function Connect(arg1:string,arg2:string,arg3:Array<string>,arg4:string):Promise<void>;
+
+export function Create(arg1:app.NetworkSpec):Promise<app.NetworkCreateResult>;
+
+export function Disconnect(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
 export function Inspect(arg1:string):Promise<string>;
 
 export function List():Promise<Array<app.NetworkInfo>>;
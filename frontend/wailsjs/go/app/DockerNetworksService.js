@@ -2,6 +2,18 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function Connect(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['DockerNetworksService']['Connect'](arg1, arg2, arg3, arg4);
+}
+
+export function Create(arg1) {
+  return window['go']['app']['DockerNetworksService']['Create'](arg1);
+}
+
+export function Disconnect(arg1, arg2, arg3) {
+  return window['go']['app']['DockerNetworksService']['Disconnect'](arg1, arg2, arg3);
+}
+
 export function Inspect(arg1) {
   return window['go']['app']['DockerNetworksService']['Inspect'](arg1);
 }
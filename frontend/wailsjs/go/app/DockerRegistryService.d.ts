@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {app} from '../models';
+
+export function List():Promise<Array<app.RegistryLoginInfo>>;
+
+export function Login(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function Logout(arg1:string):Promise<void>;
@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function List() {
+  return window['go']['app']['DockerRegistryService']['List']();
+}
+
+export function Login(arg1, arg2, arg3) {
+  return window['go']['app']['DockerRegistryService']['Login'](arg1, arg2, arg3);
+}
+
+export function Logout(arg1) {
+  return window['go']['app']['DockerRegistryService']['Logout'](arg1);
+}
@@ -0,0 +1,10 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function StartWatching(arg1:string):Promise<void>;
+
+export function StopAll():Promise<void>;
+
+export function StopWatching(arg1:string):Promise<void>;
+
+export function Watched():Promise<Array<string>>;
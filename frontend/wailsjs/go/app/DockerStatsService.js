@@ -0,0 +1,19 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function StartWatching(arg1) {
+  return window['go']['app']['DockerStatsService']['StartWatching'](arg1);
+}
+
+export function StopAll() {
+  return window['go']['app']['DockerStatsService']['StopAll']();
+}
+
+export function StopWatching(arg1) {
+  return window['go']['app']['DockerStatsService']['StopWatching'](arg1);
+}
+
+export function Watched() {
+  return window['go']['app']['DockerStatsService']['Watched']();
+}
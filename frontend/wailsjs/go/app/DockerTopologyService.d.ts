@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {app} from '../models';
+
+export function Graph():Promise<app.TopologyGraph>;
+
+export function StartWatching():Promise<void>;
+
+export function StopWatching():Promise<void>;
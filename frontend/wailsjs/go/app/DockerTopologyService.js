@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Graph() {
+  return window['go']['app']['DockerTopologyService']['Graph']();
+}
+
+export function StartWatching() {
+  return window['go']['app']['DockerTopologyService']['StartWatching']();
+}
+
+export function StopWatching() {
+  return window['go']['app']['DockerTopologyService']['StopWatching']();
+}
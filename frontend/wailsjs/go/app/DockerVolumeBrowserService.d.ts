@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {app} from '../models';
+
+export function List(arg1:string,arg2:string):Promise<Array<app.VolumeEntry>>;
+
+export function ReadFile(arg1:string,arg2:string):Promise<app.VolumeFileContent>;
+
+export function Stat(arg1:string,arg2:string):Promise<app.VolumeEntry>;
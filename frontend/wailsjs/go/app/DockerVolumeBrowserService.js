@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function List(arg1, arg2) {
+  return window['go']['app']['DockerVolumeBrowserService']['List'](arg1, arg2);
+}
+
+export function ReadFile(arg1, arg2) {
+  return window['go']['app']['DockerVolumeBrowserService']['ReadFile'](arg1, arg2);
+}
+
+export function Stat(arg1, arg2) {
+  return window['go']['app']['DockerVolumeBrowserService']['Stat'](arg1, arg2);
+}
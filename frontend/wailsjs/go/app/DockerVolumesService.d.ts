@@ -2,12 +2,22 @@
 // This file is automatically generated. DO NOT EDIT
 import {app} from '../models';
 
+export function Backup(arg1:string):Promise<string>;
+
+export function Clone(arg1:string,arg2:string):Promise<app.VolumeInfo>;
+
+export function Create(arg1:string,arg2:string,arg3:Record<string, string>,arg4:Record<string, string>):Promise<app.VolumeInfo>;
+
 export function Inspect(arg1:string):Promise<string>;
 
 export function List():Promise<Array<app.VolumeInfo>>;
 
 export function Remove(arg1:string):Promise<void>;
 
+export function Rename(arg1:string,arg2:string):Promise<app.VolumeInfo>;
+
+export function Restore(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
 export function StartWatching():Promise<void>;
 
 export function StopWatching():Promise<void>;
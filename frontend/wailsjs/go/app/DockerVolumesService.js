@@ -2,6 +2,18 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function Backup(arg1) {
+  return window['go']['app']['DockerVolumesService']['Backup'](arg1);
+}
+
+export function Clone(arg1, arg2) {
+  return window['go']['app']['DockerVolumesService']['Clone'](arg1, arg2);
+}
+
+export function Create(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['DockerVolumesService']['Create'](arg1, arg2, arg3, arg4);
+}
+
 export function Inspect(arg1) {
   return window['go']['app']['DockerVolumesService']['Inspect'](arg1);
 }
@@ -14,6 +26,14 @@ export function Remove(arg1) {
   return window['go']['app']['DockerVolumesService']['Remove'](arg1);
 }
 
+export function Rename(arg1, arg2) {
+  return window['go']['app']['DockerVolumesService']['Rename'](arg1, arg2);
+}
+
+export function Restore(arg1, arg2, arg3) {
+  return window['go']['app']['DockerVolumesService']['Restore'](arg1, arg2, arg3);
+}
+
 export function StartWatching() {
   return window['go']['app']['DockerVolumesService']['StartWatching']();
 }
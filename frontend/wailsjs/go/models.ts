@@ -1,11 +1,105 @@
 export namespace app {
 	
+	export class ComposeServiceInfo {
+	    name: string;
+	    image: string;
+	    containerName: string;
+	    dependsOn: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ComposeServiceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.image = source["image"];
+	        this.containerName = source["containerName"];
+	        this.dependsOn = source["dependsOn"];
+	    }
+	}
+	export class ComposeProjectInfo {
+	    name: string;
+	    file: string;
+	    workingDir: string;
+	    services: ComposeServiceInfo[];
+	    networks: string[];
+	    volumes: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ComposeProjectInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.file = source["file"];
+	        this.workingDir = source["workingDir"];
+	        this.services = this.convertValues(source["services"], ComposeServiceInfo);
+	        this.networks = source["networks"];
+	        this.volumes = source["volumes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ContainerActionResult {
+	    id: string;
+	    name: string;
+	    service: string;
+	    success: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerActionResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.service = source["service"];
+	        this.success = source["success"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ContainerCreateResult {
+	    id: string;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerCreateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.warnings = source["warnings"];
+	    }
+	}
 	export class ContainerInfo {
 	    id: string;
 	    names: string[];
 	    image: string;
 	    status: string;
 	    state: string;
+	    networks: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ContainerInfo(source);
@@ -18,7 +112,124 @@ export namespace app {
 	        this.image = source["image"];
 	        this.status = source["status"];
 	        this.state = source["state"];
+	        this.networks = source["networks"];
+	    }
+	}
+	export class ResourcesSpec {
+	    cpus: number;
+	    cpuShares: number;
+	    memoryBytes: number;
+	    memorySwap: number;
+	    pidsLimit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourcesSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cpus = source["cpus"];
+	        this.cpuShares = source["cpuShares"];
+	        this.memoryBytes = source["memoryBytes"];
+	        this.memorySwap = source["memorySwap"];
+	        this.pidsLimit = source["pidsLimit"];
+	    }
+	}
+	export class MountSpec {
+	    type: string;
+	    source: string;
+	    target: string;
+	    readOnly: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MountSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.readOnly = source["readOnly"];
+	    }
+	}
+	export class PortSpec {
+	    hostIp: string;
+	    hostPort: string;
+	    containerPort: string;
+	    protocol: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostIp = source["hostIp"];
+	        this.hostPort = source["hostPort"];
+	        this.containerPort = source["containerPort"];
+	        this.protocol = source["protocol"];
+	    }
+	}
+	export class ContainerSpec {
+	    name: string;
+	    image: string;
+	    cmd: string[];
+	    entrypoint: string[];
+	    env: Record<string, string>;
+	    workingDir: string;
+	    user: string;
+	    tty: boolean;
+	    ports: PortSpec[];
+	    mounts: MountSpec[];
+	    networks: string[];
+	    restartPolicy: string;
+	    maxRetries: number;
+	    labels: Record<string, string>;
+	    resources: ResourcesSpec;
+	    start: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.image = source["image"];
+	        this.cmd = source["cmd"];
+	        this.entrypoint = source["entrypoint"];
+	        this.env = source["env"];
+	        this.workingDir = source["workingDir"];
+	        this.user = source["user"];
+	        this.tty = source["tty"];
+	        this.ports = this.convertValues(source["ports"], PortSpec);
+	        this.mounts = this.convertValues(source["mounts"], MountSpec);
+	        this.networks = source["networks"];
+	        this.restartPolicy = source["restartPolicy"];
+	        this.maxRetries = source["maxRetries"];
+	        this.labels = source["labels"];
+	        this.resources = this.convertValues(source["resources"], ResourcesSpec);
+	        this.start = source["start"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class ContainersGroup {
 	    name: string;
@@ -52,58 +263,756 @@ export namespace app {
 		    return a;
 		}
 	}
-	export class ImageInfo {
+	export class ImageBuildSpec {
+	    contextDir: string;
+	    dockerfile: string;
+	    tags: string[];
+	    buildArgs: Record<string, string>;
+	    target: string;
+	    noCache: boolean;
+	    pull: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageBuildSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contextDir = source["contextDir"];
+	        this.dockerfile = source["dockerfile"];
+	        this.tags = source["tags"];
+	        this.buildArgs = source["buildArgs"];
+	        this.target = source["target"];
+	        this.noCache = source["noCache"];
+	        this.pull = source["pull"];
+	    }
+	}
+	export class ImageDependent {
 	    id: string;
+	    name: string;
+	    image: string;
+	    state: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageDependent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.image = source["image"];
+	        this.state = source["state"];
+	    }
+	}
+	export class InstructionSummary {
+	    instruction: string;
+	    layers: number;
 	    size: number;
-	    tags: string[];
+	    percent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstructionSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instruction = source["instruction"];
+	        this.layers = source["layers"];
+	        this.size = source["size"];
+	        this.percent = source["percent"];
+	    }
+	}
+	export class ImageLayer {
+	    id: string;
+	    instruction: string;
+	    createdBy: string;
+	    size: number;
+	    percent: number;
 	    createdAt: string;
+	    tags: string[];
+	    comment: string;
+	    empty: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ImageInfo(source);
+	        return new ImageLayer(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
+	        this.instruction = source["instruction"];
+	        this.createdBy = source["createdBy"];
 	        this.size = source["size"];
-	        this.tags = source["tags"];
+	        this.percent = source["percent"];
 	        this.createdAt = source["createdAt"];
+	        this.tags = source["tags"];
+	        this.comment = source["comment"];
+	        this.empty = source["empty"];
 	    }
 	}
-	export class NetworkInfo {
+	export class ImageHistory {
 	    id: string;
-	    name: string;
+	    size: number;
+	    layers: ImageLayer[];
+	    instructions: InstructionSummary[];
+	    largest: ImageLayer[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NetworkInfo(source);
+	        return new ImageHistory(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
+	        this.size = source["size"];
+	        this.layers = this.convertValues(source["layers"], ImageLayer);
+	        this.instructions = this.convertValues(source["instructions"], InstructionSummary);
+	        this.largest = this.convertValues(source["largest"], ImageLayer);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class VolumeInfo {
+	export class ImageInfo {
 	    id: string;
-	    name: string;
 	    size: number;
 	    tags: string[];
 	    createdAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new VolumeInfo(source);
+	        return new ImageInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
 	        this.size = source["size"];
 	        this.tags = source["tags"];
 	        this.createdAt = source["createdAt"];
 	    }
 	}
+	
+	export class ImagePruneCandidate {
+	    id: string;
+	    tags: string[];
+	    size: number;
+	    reclaimable: number;
+	    createdAt: string;
+	    dangling: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImagePruneCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.tags = source["tags"];
+	        this.size = source["size"];
+	        this.reclaimable = source["reclaimable"];
+	        this.createdAt = source["createdAt"];
+	        this.dangling = source["dangling"];
+	    }
+	}
+	export class ImagePruneOptions {
+	    all: boolean;
+	    olderThanDays: number;
+	    labels: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImagePruneOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.all = source["all"];
+	        this.olderThanDays = source["olderThanDays"];
+	        this.labels = source["labels"];
+	    }
+	}
+	export class ImagePrunePreview {
+	    images: ImagePruneCandidate[];
+	    reclaimableBytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImagePrunePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.images = this.convertValues(source["images"], ImagePruneCandidate);
+	        this.reclaimableBytes = source["reclaimableBytes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImagePruneReport {
+	    deleted: string[];
+	    untagged: string[];
+	    reclaimedBytes: number;
+	    errors: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImagePruneReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deleted = source["deleted"];
+	        this.untagged = source["untagged"];
+	        this.reclaimedBytes = source["reclaimedBytes"];
+	        this.errors = source["errors"];
+	    }
+	}
+	export class ImageRemoveResult {
+	    removed: boolean;
+	    conflicts: ImageDependent[];
+	    deleted: string[];
+	    untagged: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageRemoveResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.removed = source["removed"];
+	        this.conflicts = this.convertValues(source["conflicts"], ImageDependent);
+	        this.deleted = source["deleted"];
+	        this.untagged = source["untagged"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImageSearchResult {
+	    name: string;
+	    description: string;
+	    stars: number;
+	    official: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageSearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.stars = source["stars"];
+	        this.official = source["official"];
+	    }
+	}
+	
+	export class LogEntry {
+	    streamId: string;
+	    containerId: string;
+	    containerName: string;
+	    stream: string;
+	    timestamp: string;
+	    text: string;
+	    level: string;
+	    match: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.streamId = source["streamId"];
+	        this.containerId = source["containerId"];
+	        this.containerName = source["containerName"];
+	        this.stream = source["stream"];
+	        this.timestamp = source["timestamp"];
+	        this.text = source["text"];
+	        this.level = source["level"];
+	        this.match = source["match"];
+	    }
+	}
+	export class LogFilter {
+	    include: string[];
+	    exclude: string[];
+	    search: string;
+	    minLevel: string;
+	    before: number;
+	    after: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.include = source["include"];
+	        this.exclude = source["exclude"];
+	        this.search = source["search"];
+	        this.minLevel = source["minLevel"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	    }
+	}
+	export class LogQuery {
+	    tail: string;
+	    since: string;
+	    until: string;
+	    follow: boolean;
+	    timestamps: boolean;
+	    filter: LogFilter;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tail = source["tail"];
+	        this.since = source["since"];
+	        this.until = source["until"];
+	        this.follow = source["follow"];
+	        this.timestamps = source["timestamps"];
+	        this.filter = this.convertValues(source["filter"], LogFilter);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class NetworkContainer {
+	    id: string;
+	    name: string;
+	    ipv4Address: string;
+	    ipv6Address: string;
+	    macAddress: string;
+	    aliases: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkContainer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.ipv4Address = source["ipv4Address"];
+	        this.ipv6Address = source["ipv6Address"];
+	        this.macAddress = source["macAddress"];
+	        this.aliases = source["aliases"];
+	    }
+	}
+	export class NetworkCreateResult {
+	    id: string;
+	    warning: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkCreateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class NetworkSubnet {
+	    subnet: string;
+	    gateway: string;
+	    ipRange: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkSubnet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subnet = source["subnet"];
+	        this.gateway = source["gateway"];
+	        this.ipRange = source["ipRange"];
+	    }
+	}
+	export class NetworkInfo {
+	    id: string;
+	    name: string;
+	    driver: string;
+	    scope: string;
+	    internal: boolean;
+	    attachable: boolean;
+	    enableIPv6: boolean;
+	    subnets: NetworkSubnet[];
+	    createdAt: string;
+	    labels: Record<string, string>;
+	    containers: NetworkContainer[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.driver = source["driver"];
+	        this.scope = source["scope"];
+	        this.internal = source["internal"];
+	        this.attachable = source["attachable"];
+	        this.enableIPv6 = source["enableIPv6"];
+	        this.subnets = this.convertValues(source["subnets"], NetworkSubnet);
+	        this.createdAt = source["createdAt"];
+	        this.labels = source["labels"];
+	        this.containers = this.convertValues(source["containers"], NetworkContainer);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SubnetSpec {
+	    subnet: string;
+	    gateway: string;
+	    ipRange: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubnetSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subnet = source["subnet"];
+	        this.gateway = source["gateway"];
+	        this.ipRange = source["ipRange"];
+	    }
+	}
+	export class NetworkSpec {
+	    name: string;
+	    driver: string;
+	    subnets: SubnetSpec[];
+	    internal: boolean;
+	    attachable: boolean;
+	    enableIPv6: boolean;
+	    options: Record<string, string>;
+	    labels: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.driver = source["driver"];
+	        this.subnets = this.convertValues(source["subnets"], SubnetSpec);
+	        this.internal = source["internal"];
+	        this.attachable = source["attachable"];
+	        this.enableIPv6 = source["enableIPv6"];
+	        this.options = source["options"];
+	        this.labels = source["labels"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class RegistryLoginInfo {
+	    serverAddress: string;
+	    username: string;
+	    store: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RegistryLoginInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.serverAddress = source["serverAddress"];
+	        this.username = source["username"];
+	        this.store = source["store"];
+	    }
+	}
+	
+	
+	export class TopologyEdge {
+	    id: string;
+	    source: string;
+	    target: string;
+	    type: string;
+	    label: string;
+	    ipv4Address: string;
+	    ipv6Address: string;
+	    aliases: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TopologyEdge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.type = source["type"];
+	        this.label = source["label"];
+	        this.ipv4Address = source["ipv4Address"];
+	        this.ipv6Address = source["ipv6Address"];
+	        this.aliases = source["aliases"];
+	    }
+	}
+	export class TopologyNode {
+	    id: string;
+	    type: string;
+	    label: string;
+	    state: string;
+	    driver: string;
+	    subnets: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TopologyNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.label = source["label"];
+	        this.state = source["state"];
+	        this.driver = source["driver"];
+	        this.subnets = source["subnets"];
+	    }
+	}
+	export class TopologyGraph {
+	    nodes: TopologyNode[];
+	    edges: TopologyEdge[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TopologyGraph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodes = this.convertValues(source["nodes"], TopologyNode);
+	        this.edges = this.convertValues(source["edges"], TopologyEdge);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class VolumeContainer {
+	    id: string;
+	    name: string;
+	    state: string;
+	    destination: string;
+	    readOnly: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeContainer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.state = source["state"];
+	        this.destination = source["destination"];
+	        this.readOnly = source["readOnly"];
+	    }
+	}
+	export class VolumeEntry {
+	    name: string;
+	    path: string;
+	    type: string;
+	    size: number;
+	    mode: string;
+	    modifiedAt: string;
+	    linkTarget: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.type = source["type"];
+	        this.size = source["size"];
+	        this.mode = source["mode"];
+	        this.modifiedAt = source["modifiedAt"];
+	        this.linkTarget = source["linkTarget"];
+	    }
+	}
+	export class VolumeFileContent {
+	    path: string;
+	    size: number;
+	    content: string;
+	    truncated: boolean;
+	    binary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeFileContent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.content = source["content"];
+	        this.truncated = source["truncated"];
+	        this.binary = source["binary"];
+	    }
+	}
+	export class VolumeInfo {
+	    id: string;
+	    name: string;
+	    size: number;
+	    refCount: number;
+	    tags: string[];
+	    driver: string;
+	    createdAt: string;
+	    containers: VolumeContainer[];
+	    orphaned: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.size = source["size"];
+	        this.refCount = source["refCount"];
+	        this.tags = source["tags"];
+	        this.driver = source["driver"];
+	        this.createdAt = source["createdAt"];
+	        this.containers = this.convertValues(source["containers"], VolumeContainer);
+	        this.orphaned = source["orphaned"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
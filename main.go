@@ -22,6 +22,11 @@ var dockerVolumesService *app.DockerVolumesService
 var dockerNetworksService *app.DockerNetworksService
 var dockerLogsService *app.DockerLogsService
 var dockerTerminalService *app.DockerContainersTerminal
+var dockerStatsService *app.DockerStatsService
+var dockerComposeService *app.DockerComposeService
+var dockerRegistryService *app.DockerRegistryService
+var dockerVolumeBrowserService *app.DockerVolumeBrowserService
+var dockerTopologyService *app.DockerTopologyService
 
 func main() {
 	// Create an instance of the app structure
@@ -32,6 +37,11 @@ func main() {
 	dockerNetworksService = app.NewDockerNetworksService()
 	dockerLogsService = app.NewDockerLogsService()
 	dockerTerminalService = app.NewDockerTerminalService()
+	dockerStatsService = app.NewDockerStatsService()
+	dockerComposeService = app.NewDockerComposeService()
+	dockerRegistryService = app.NewDockerRegistryService()
+	dockerVolumeBrowserService = app.NewDockerVolumeBrowserService()
+	dockerTopologyService = app.NewDockerTopologyService()
 
 	// Create application with options
 	err := wails.Run(&options.App{
@@ -54,6 +64,11 @@ func main() {
 			dockerNetworksService,
 			dockerLogsService,
 			dockerTerminalService,
+			dockerStatsService,
+			dockerComposeService,
+			dockerRegistryService,
+			dockerVolumeBrowserService,
+			dockerTopologyService,
 		},
 	})
 
@@ -75,4 +90,9 @@ func startup(ctx context.Context) {
 	app.StartupDockerNetworksService(dockerNetworksService, ctx, cli)
 	app.StartupDockerLogsService(dockerLogsService, ctx, cli)
 	app.StartupDockerTerminalService(dockerTerminalService, ctx, cli)
+	app.StartupDockerStatsService(dockerStatsService, ctx, cli)
+	app.StartupDockerComposeService(dockerComposeService, ctx, cli)
+	app.StartupDockerRegistryService(dockerRegistryService, ctx, cli)
+	app.StartupDockerVolumeBrowserService(dockerVolumeBrowserService, ctx, cli)
+	app.StartupDockerTopologyService(dockerTopologyService, ctx, cli)
 }